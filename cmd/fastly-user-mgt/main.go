@@ -0,0 +1,393 @@
+// Command fastly-user-mgt manages Fastly users and invitations from the
+// command line, using the same client and logic as the Terraform provider.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+
+	"github.com/fastly/terraform-provider-fastly-user-mgt/fastly"
+	"github.com/fastly/terraform-provider-fastly-user-mgt/version"
+)
+
+const usage = `Usage: fastly-user-mgt <command> [options]
+
+Commands:
+  users list                        List the users of the account
+  invite <email> [-role ROLE]       Invite a user (role defaults to "user")
+  invitations list                  List invitations
+  invitations revoke <id|email>     Revoke an invitation
+  user lock <login> [-unlock]       Lock a user, or unlock it with -unlock
+  tokens list                       List the API tokens of all users
+
+Every command accepts -output table|json (default table).
+
+The API key and URL are read from FASTLY_API_KEY and FASTLY_API_URL.
+`
+
+// errUsage is returned for invalid command lines, after printing usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	err := dispatch(args, stdout, stderr)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		return 2
+	default:
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+}
+
+func dispatch(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	command := args[0]
+	if len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+		switch command {
+		case "users", "invitations", "user", "tokens":
+			command += " " + args[1]
+			args = args[1:]
+		}
+	}
+
+	var cmd func(*cli, []string) error
+	switch command {
+	case "users list":
+		cmd = (*cli).usersList
+	case "invite":
+		cmd = (*cli).invite
+	case "invitations list":
+		cmd = (*cli).invitationsList
+	case "invitations revoke":
+		cmd = (*cli).invitationsRevoke
+	case "user lock":
+		cmd = (*cli).userLock
+	case "tokens list":
+		cmd = (*cli).tokensList
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", command, usage)
+		return errUsage
+	}
+
+	return cmd(newCLI(command, stdout, stderr), args[1:])
+}
+
+// cli holds the state of a single command.
+type cli struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+	flags  *flag.FlagSet
+	output string
+}
+
+// newCLI returns the state for the named command, with the flags shared by
+// every command already defined.
+func newCLI(command string, stdout, stderr io.Writer) *cli {
+	c := &cli{
+		ctx:    context.Background(),
+		stdout: stdout,
+		stderr: stderr,
+		flags:  flag.NewFlagSet(command, flag.ContinueOnError),
+	}
+	c.flags.SetOutput(stderr)
+	c.flags.StringVar(&c.output, "output", "table", "output format, table or json")
+	return c
+}
+
+// parse parses flags, which may come before or after the positional
+// arguments, and checks the number of positional arguments.
+func (c *cli) parse(args []string, positional ...string) ([]string, error) {
+	var values []string
+	for {
+		if err := c.flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			// The flag package has already printed the error and usage.
+			return nil, errUsage
+		}
+		if c.flags.NArg() == 0 {
+			break
+		}
+		values = append(values, c.flags.Arg(0))
+		args = c.flags.Args()[1:]
+	}
+
+	if len(values) != len(positional) {
+		want := "no arguments"
+		if len(positional) > 0 {
+			want = "<" + strings.Join(positional, "> <") + ">"
+		}
+		fmt.Fprintf(c.stderr, "%s expects %s\n", c.flags.Name(), want)
+		return nil, errUsage
+	}
+	if c.output != "table" && c.output != "json" {
+		fmt.Fprintf(c.stderr, "unknown output format %q, expected table or json\n", c.output)
+		return nil, errUsage
+	}
+	return values, nil
+}
+
+// client returns an API client configured like the provider.
+func (c *cli) client() (*fastly.APIClient, error) {
+	config := fastly.EnvConfig(c.ctx, fmt.Sprintf("fastly-user-mgt/%s", version.ProviderVersion))
+
+	client, diags := config.Client()
+	if diags.HasError() {
+		msgs := make([]string, 0, len(diags))
+		for _, d := range diags {
+			msgs = append(msgs, d.Summary)
+		}
+		return nil, errors.New(strings.Join(msgs, "; "))
+	}
+	return client, nil
+}
+
+func (c *cli) usersList(args []string) error {
+	if _, err := c.parse(args); err != nil {
+		return err
+	}
+	client, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	users, err := client.ListUsers(c.ctx)
+	if err != nil {
+		return err
+	}
+	return c.printUsers(users)
+}
+
+func (c *cli) invite(args []string) error {
+	var role string
+	c.flags.StringVar(&role, "role", "user", "role of the new user: user, billing, engineer or superuser")
+	values, err := c.parse(args, "email")
+	if err != nil {
+		return err
+	}
+	client, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	inv, err := client.Invite(c.ctx, values[0], role)
+	if err != nil {
+		return err
+	}
+	return c.printInvitations([]*fastly.Invitation{inv})
+}
+
+func (c *cli) invitationsList(args []string) error {
+	if _, err := c.parse(args); err != nil {
+		return err
+	}
+	client, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	invitations, err := client.ListInvitations(c.ctx)
+	if err != nil {
+		return err
+	}
+	return c.printInvitations(invitations)
+}
+
+func (c *cli) invitationsRevoke(args []string) error {
+	values, err := c.parse(args, "id|email")
+	if err != nil {
+		return err
+	}
+	client, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	inv, err := client.RevokeInvitation(c.ctx, values[0])
+	if err != nil {
+		return err
+	}
+	return c.printInvitations([]*fastly.Invitation{inv})
+}
+
+func (c *cli) userLock(args []string) error {
+	var unlock bool
+	c.flags.BoolVar(&unlock, "unlock", false, "unlock the user instead")
+	values, err := c.parse(args, "login")
+	if err != nil {
+		return err
+	}
+	client, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	u, err := client.SetUserLocked(c.ctx, values[0], !unlock)
+	if err != nil {
+		return err
+	}
+	return c.printUsers([]*gofastly.User{u})
+}
+
+func (c *cli) tokensList(args []string) error {
+	if _, err := c.parse(args); err != nil {
+		return err
+	}
+	client, err := c.client()
+	if err != nil {
+		return err
+	}
+
+	tokens, err := client.ListTokens(c.ctx)
+	if err != nil {
+		return err
+	}
+	return c.printTokens(tokens)
+}
+
+type userOutput struct {
+	ID                   string `json:"id"`
+	Login                string `json:"login"`
+	Name                 string `json:"name"`
+	Role                 string `json:"role"`
+	Locked               bool   `json:"locked"`
+	TwoFactorAuthEnabled bool   `json:"two_factor_auth_enabled"`
+	LimitServices        bool   `json:"limit_services"`
+	CreatedAt            string `json:"created_at"`
+}
+
+func (c *cli) printUsers(users []*gofastly.User) error {
+	rows := make([]userOutput, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, userOutput{
+			ID:                   gofastly.ToValue(u.UserID),
+			Login:                gofastly.ToValue(u.Login),
+			Name:                 gofastly.ToValue(u.Name),
+			Role:                 gofastly.ToValue(u.Role),
+			Locked:               gofastly.ToValue(u.Locked),
+			TwoFactorAuthEnabled: gofastly.ToValue(u.TwoFactorAuthEnabled),
+			LimitServices:        gofastly.ToValue(u.LimitServices),
+			CreatedAt:            formatTime(u.CreatedAt),
+		})
+	}
+	if c.output == "json" {
+		return c.printJSON(rows)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOGIN\tNAME\tROLE\tLOCKED\t2FA\tID")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\t%s\n", r.Login, r.Name, r.Role, r.Locked, r.TwoFactorAuthEnabled, r.ID)
+	}
+	return w.Flush()
+}
+
+type invitationOutput struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at"`
+	InvitedBy string `json:"invited_by"`
+}
+
+func (c *cli) printInvitations(invitations []*fastly.Invitation) error {
+	rows := make([]invitationOutput, 0, len(invitations))
+	for _, inv := range invitations {
+		rows = append(rows, invitationOutput{
+			ID:        inv.ID,
+			Email:     inv.Email,
+			Role:      inv.Role,
+			Status:    inv.Status(),
+			CreatedAt: inv.CreatedAt,
+			ExpiresAt: inv.ExpiresAt,
+			InvitedBy: inv.InvitedBy,
+		})
+	}
+	if c.output == "json" {
+		return c.printJSON(rows)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EMAIL\tROLE\tSTATUS\tCREATED\tEXPIRES\tID")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Email, r.Role, r.Status, r.CreatedAt, r.ExpiresAt, r.ID)
+	}
+	return w.Flush()
+}
+
+type tokenOutput struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	UserID     string   `json:"user_id"`
+	Scope      string   `json:"scope"`
+	Services   []string `json:"services"`
+	CreatedAt  string   `json:"created_at"`
+	ExpiresAt  string   `json:"expires_at"`
+	LastUsedAt string   `json:"last_used_at"`
+}
+
+func (c *cli) printTokens(tokens []*gofastly.Token) error {
+	rows := make([]tokenOutput, 0, len(tokens))
+	for _, t := range tokens {
+		rows = append(rows, tokenOutput{
+			ID:         gofastly.ToValue(t.TokenID),
+			Name:       gofastly.ToValue(t.Name),
+			UserID:     gofastly.ToValue(t.UserID),
+			Scope:      string(gofastly.ToValue(t.Scope)),
+			Services:   t.Services,
+			CreatedAt:  formatTime(t.CreatedAt),
+			ExpiresAt:  formatTime(t.ExpiresAt),
+			LastUsedAt: formatTime(t.LastUsedAt),
+		})
+	}
+	if c.output == "json" {
+		return c.printJSON(rows)
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tUSER ID\tSCOPE\tEXPIRES\tLAST USED\tID")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Name, r.UserID, r.Scope, r.ExpiresAt, r.LastUsedAt, r.ID)
+	}
+	return w.Flush()
+}
+
+func (c *cli) printJSON(v any) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
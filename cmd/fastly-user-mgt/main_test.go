@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunUsage(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"no command", nil, 2, "", "Usage: fastly-user-mgt"},
+		{"help", []string{"help"}, 0, "Usage: fastly-user-mgt", ""},
+		{"unknown command", []string{"groups", "list"}, 2, "", `unknown command "groups"`},
+		{"unknown subcommand", []string{"users", "delete"}, 2, "", `unknown command "users delete"`},
+		{"missing subcommand", []string{"users"}, 2, "", `unknown command "users"`},
+		{"unknown flag", []string{"users", "list", "-verbose"}, 2, "", "flag provided but not defined: -verbose"},
+		{"bad output", []string{"users", "list", "-output", "yaml"}, 2, "", `unknown output format "yaml"`},
+		{"unexpected argument", []string{"users", "list", "jane@example.com"}, 2, "", "users list expects no arguments"},
+		{"missing argument", []string{"invite", "-role", "engineer"}, 2, "", "invite expects <email>"},
+		{"too many arguments", []string{"user", "lock", "jane@example.com", "john@example.com"}, 2, "", "user lock expects <login>"},
+		{"flag for another command", []string{"invite", "jane@example.com", "-unlock"}, 2, "", "flag provided but not defined: -unlock"},
+	}
+
+	for _, tc := range cases {
+		var stdout, stderr bytes.Buffer
+		if code := run(tc.args, &stdout, &stderr); code != tc.code {
+			t.Errorf("%s: run(%q) = %d, want %d (stderr: %s)", tc.name, tc.args, code, tc.code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), tc.stdout) {
+			t.Errorf("%s: stdout = %q, want it to contain %q", tc.name, stdout.String(), tc.stdout)
+		}
+		if !strings.Contains(stderr.String(), tc.stderr) {
+			t.Errorf("%s: stderr = %q, want it to contain %q", tc.name, stderr.String(), tc.stderr)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name       string
+		args       []string
+		positional []string
+		want       []string
+		output     string
+		role       string
+		wantErr    bool
+	}{
+		{"flags after argument", []string{"jane@example.com", "-role", "engineer", "-output", "json"}, []string{"email"}, []string{"jane@example.com"}, "json", "engineer", false},
+		{"flags before argument", []string{"-output=json", "jane@example.com"}, []string{"email"}, []string{"jane@example.com"}, "json", "user", false},
+		{"flags around argument", []string{"-role", "billing", "jane@example.com", "-output", "table"}, []string{"email"}, []string{"jane@example.com"}, "table", "billing", false},
+		{"no arguments", nil, nil, nil, "table", "user", false},
+		{"terminator", []string{"--", "-jane@example.com"}, []string{"email"}, []string{"-jane@example.com"}, "table", "user", false},
+		{"bad output", []string{"jane@example.com", "-output", "yaml"}, []string{"email"}, nil, "", "", true},
+		{"missing argument", []string{"-output", "json"}, []string{"email"}, nil, "", "", true},
+		{"extra argument", []string{"jane@example.com", "john@example.com"}, []string{"email"}, nil, "", "", true},
+	}
+
+	for _, tc := range cases {
+		var stderr bytes.Buffer
+		c := newCLI("invite", &bytes.Buffer{}, &stderr)
+		var role string
+		c.flags.StringVar(&role, "role", "user", "")
+
+		got, err := c.parse(tc.args, tc.positional...)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: parse(%q) succeeded, want an error", tc.name, tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: parse(%q) failed: %s (stderr: %s)", tc.name, tc.args, err, stderr.String())
+			continue
+		}
+		if strings.Join(got, " ") != strings.Join(tc.want, " ") || c.output != tc.output || role != tc.role {
+			t.Errorf("%s: parse(%q) = %q, output %q, role %q; want %q, output %q, role %q", tc.name, tc.args, got, c.output, role, tc.want, tc.output, tc.role)
+		}
+	}
+}
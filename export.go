@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fastly/terraform-provider-fastly-user-mgt/fastly"
+	"github.com/fastly/terraform-provider-fastly-user-mgt/version"
+)
+
+// runExport implements the export subcommand, which writes fastly_user
+// configuration and import blocks for an existing account. It reads the API
+// key and URL from the same environment variables as the provider.
+func runExport(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s export [options]\n\n", os.Args[0])
+		fmt.Fprintf(stderr, "Writes fastly_user resources and import blocks for the users of the account\n")
+		fmt.Fprintf(stderr, "that owns FASTLY_API_KEY to standard output.\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+
+	format := flags.String("format", fastly.ExportFormatHCL, "output format, hcl or json")
+	includeInvitations := flags.Bool("include-invitations", true, "also export pending invitations")
+	roster := flags.Bool("roster", false, "write a map of users and a single fastly_user resource using for_each")
+	caseInsensitive := flags.Bool("login-case-insensitive", fastly.DefaultLoginNormalization.CaseInsensitive, "match logins ignoring case, as the provider's login_case_insensitive")
+	ignorePlusSuffix := flags.Bool("login-ignore-plus-suffix", fastly.DefaultLoginNormalization.IgnorePlusSuffix, "match user+tag@example.com as user@example.com, as the provider's login_ignore_plus_suffix")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %v\n", flags.Args())
+		flags.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+	config := fastly.EnvConfig(ctx, fmt.Sprintf("%s/%s", fastly.TerraformProviderProductUserAgent, version.ProviderVersion))
+	config.LoginNormalization = fastly.LoginNormalization{
+		CaseInsensitive:  *caseInsensitive,
+		IgnorePlusSuffix: *ignorePlusSuffix,
+	}
+
+	client, diags := config.Client()
+	if diags.HasError() {
+		for _, d := range diags {
+			fmt.Fprintf(stderr, "Error: %s\n", d.Summary)
+		}
+		return 1
+	}
+
+	err := fastly.Export(ctx, client, stdout, fastly.ExportOptions{
+		Format:             *format,
+		IncludeInvitations: *includeInvitations,
+		Roster:             *roster,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,96 @@
+package fastly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+var (
+	_ action.Action              = &lockUserAction{}
+	_ action.ActionWithConfigure = &lockUserAction{}
+)
+
+// lockUserAction locks or unlocks a user without managing it as a
+// fastly_user.
+type lockUserAction struct {
+	client *APIClient
+}
+
+type lockUserModel struct {
+	Login  types.String `tfsdk:"login"`
+	Locked types.Bool   `tfsdk:"locked"`
+}
+
+func newLockUserAction() action.Action {
+	return &lockUserAction{}
+}
+
+func (a *lockUserAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lock_user"
+}
+
+func (a *lockUserAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Locks a user out of the account, or unlocks it",
+		Attributes: map[string]schema.Attribute{
+			"login": schema.StringAttribute{
+				Required:    true,
+				Description: "The login of the user, compared using the provider's login normalization rules",
+			},
+			"locked": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Set to `false` to unlock the user instead. Default: `true`",
+			},
+		},
+	}
+}
+
+func (a *lockUserAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = frameworkAPIClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *lockUserAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if !checkConfiguredClient(a.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data lockUserModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	login := data.Login.ValueString()
+	locked := true
+	if !data.Locked.IsNull() {
+		locked = data.Locked.ValueBool()
+	}
+
+	u, err := a.client.SetUserLocked(ctx, login, locked)
+	switch {
+	case errors.Is(err, errUserLookup):
+		resp.Diagnostics.AddError("Error looking up user", err.Error())
+		return
+	case errors.Is(err, errUserNotFound):
+		resp.Diagnostics.AddError("User not found", err.Error())
+		return
+	case err != nil:
+		resp.Diagnostics.AddError("Error updating user", err.Error())
+		return
+	}
+
+	verb := "Unlocked"
+	if locked {
+		verb = "Locked"
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("%s user %s", verb, gofastly.ToValue(u.Login)),
+	})
+}
@@ -0,0 +1,100 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ action.Action              = &resendInvitationAction{}
+	_ action.ActionWithConfigure = &resendInvitationAction{}
+)
+
+// resendInvitationAction sends a pending invitation again. The API cannot
+// resend an invitation, so it is replaced with a new one, like a role change
+// on a pending fastly_user does.
+type resendInvitationAction struct {
+	client *APIClient
+}
+
+type resendInvitationModel struct {
+	Login types.String `tfsdk:"login"`
+	Role  types.String `tfsdk:"role"`
+}
+
+func newResendInvitationAction() action.Action {
+	return &resendInvitationAction{}
+}
+
+func (a *resendInvitationAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resend_invitation"
+}
+
+func (a *resendInvitationAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends a pending invitation again by replacing it with a new one",
+		Attributes: map[string]schema.Attribute{
+			"login": schema.StringAttribute{
+				Required:    true,
+				Description: "The email address the invitation was sent to, compared using the provider's login normalization rules",
+			},
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Description: "The role of the new invitation. Defaults to the role of the pending invitation",
+			},
+		},
+	}
+}
+
+func (a *resendInvitationAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = frameworkAPIClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *resendInvitationAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if !checkConfiguredClient(a.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data resendInvitationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	login := data.Login.ValueString()
+
+	inv, err := findInvitationByEmail(ctx, a.client, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error looking up invitation", err.Error())
+		return
+	}
+	if inv == nil {
+		resp.Diagnostics.AddError("Invitation not found", fmt.Sprintf("there is no pending invitation for %s", login))
+		return
+	}
+
+	role := inv.Role
+	if !data.Role.IsNull() {
+		role = data.Role.ValueString()
+	}
+	if _, err := roleRank(role); err != nil {
+		resp.Diagnostics.AddError("Invalid role", err.Error())
+		return
+	}
+
+	invitation, err := reissueInvitation(ctx, a.client, inv.ID, inv.Email, role)
+	if err != nil {
+		resp.Diagnostics.AddError("Error resending invitation", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Resent invitation", map[string]any{"login": inv.Email, "old_invitation_id": inv.ID, "invitation_id": invitation.Data.ID})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Resent invitation to %s with role %s", inv.Email, role),
+	})
+}
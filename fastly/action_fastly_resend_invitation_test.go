@@ -0,0 +1,84 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccFastlyResendInvitationAction checks that resending an invitation
+// replaces it, and that fastly_user follows the new invitation. Actions
+// require Terraform >= 1.14.
+func TestAccFastlyResendInvitationAction(t *testing.T) {
+	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	var invitationID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckUserOrInvitationDestroy,
+		Steps: []resource.TestStep{
+			{
+				// The action runs after the invitation is created, so state
+				// still holds the original invitation at this point.
+				Config: testAccResendInvitationActionConfig(login, name),
+				Check:  testAccCheckFastlyUserAttr("invitation_id", &invitationID),
+			},
+			{
+				Config: testAccResendInvitationActionConfig(login, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFastlyInvitationExists(),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "status", "pending"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[fastlyUser]
+						if rs.Primary.Attributes["invitation_id"] == invitationID {
+							return fmt.Errorf("expected invitation %s to be resent", invitationID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccResendInvitationActionConfig(login, name string) string {
+	return fmt.Sprintf(`
+action "fastly_resend_invitation" "foo" {
+	config {
+		login = "%[1]s"
+	}
+}
+
+resource "fastly_user" "foo" {
+	login = "%[1]s"
+	name  = "%[2]s"
+	role  = "engineer"
+
+	lifecycle {
+		action_trigger {
+			events  = [after_create]
+			actions = [action.fastly_resend_invitation.foo]
+		}
+	}
+}`, login, name)
+}
+
+func TestActionsUnconfigured(t *testing.T) {
+	for _, a := range []action.Action{newResendInvitationAction(), newResetUserPasswordAction(), newLockUserAction()} {
+		var resp action.InvokeResponse
+		a.Invoke(context.Background(), action.InvokeRequest{}, &resp)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unconfigured provider" {
+			t.Errorf("%T: expected an unconfigured provider error, got %v", a, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+var (
+	_ action.Action              = &resetUserPasswordAction{}
+	_ action.ActionWithConfigure = &resetUserPasswordAction{}
+)
+
+// resetUserPasswordAction sends a password reset email to a user, like a
+// change of password_reset_trigger on fastly_user does.
+type resetUserPasswordAction struct {
+	client *APIClient
+}
+
+type resetUserPasswordModel struct {
+	Login types.String `tfsdk:"login"`
+}
+
+func newResetUserPasswordAction() action.Action {
+	return &resetUserPasswordAction{}
+}
+
+func (a *resetUserPasswordAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reset_user_password"
+}
+
+func (a *resetUserPasswordAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends a password reset email to a user",
+		Attributes: map[string]schema.Attribute{
+			"login": schema.StringAttribute{
+				Required:    true,
+				Description: "The login of the user, compared using the provider's login normalization rules",
+			},
+		},
+	}
+}
+
+func (a *resetUserPasswordAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	a.client = frameworkAPIClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (a *resetUserPasswordAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if !checkConfiguredClient(a.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data resetUserPasswordModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	login := data.Login.ValueString()
+
+	// The API accepts any login, so check the user exists to report typos
+	// instead of silently sending nothing.
+	u, err := findUserByLogin(ctx, a.client, login)
+	if err != nil {
+		resp.Diagnostics.AddError("Error looking up user", err.Error())
+		return
+	}
+	if u == nil {
+		resp.Diagnostics.AddError("User not found", fmt.Sprintf("there is no user with login %s; users that have not accepted their invitation have no password to reset", login))
+		return
+	}
+
+	login = gofastly.ToValue(u.Login)
+	if err := a.client.conn.ResetUserPassword(ctx, &gofastly.ResetUserPasswordInput{Login: login}); err != nil {
+		resp.Diagnostics.AddError("Error requesting password reset", fmt.Sprintf("%s: %s", login, err))
+		return
+	}
+
+	tflog.Debug(ctx, "Requested password reset", map[string]any{"login": login})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sent a password reset email to %s", login),
+	})
+}
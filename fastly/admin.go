@@ -0,0 +1,174 @@
+package fastly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+// The methods in this file expose the provider's user and invitation logic to
+// tools outside Terraform, such as cmd/fastly-user-mgt, so that they behave
+// exactly like the provider does.
+
+// EnvConfig returns a Config for use outside Terraform. The API key and URL
+// are read from the same environment variables as the provider.
+func EnvConfig(ctx context.Context, userAgent string) Config {
+	baseURL := os.Getenv("FASTLY_API_URL")
+	if baseURL == "" {
+		baseURL = gofastly.DefaultEndpoint
+	}
+
+	return Config{
+		APIKey:             os.Getenv("FASTLY_API_KEY"),
+		BaseURL:            baseURL,
+		UserAgent:          userAgent,
+		Context:            ctx,
+		LoginNormalization: DefaultLoginNormalization,
+	}
+}
+
+// ListUsers returns the users of the account that owns the API key, sorted by
+// login.
+func (c *APIClient) ListUsers(ctx context.Context) ([]*gofastly.User, error) {
+	_, users, _, err := listUsersAndInvitations(ctx, c, false)
+	return users, err
+}
+
+// ListInvitations returns all invitations of the account, including expired
+// ones.
+func (c *APIClient) ListInvitations(ctx context.Context) ([]*Invitation, error) {
+	resp, err := listInvitations(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*Invitation, 0, len(resp.Data))
+	for _, v := range resp.Data {
+		invitations = append(invitations, flattenInvitation(v))
+	}
+	return invitations, nil
+}
+
+// Invite sends an invitation, like creating a fastly_user with
+// on_existing = "error" does.
+func (c *APIClient) Invite(ctx context.Context, email, role string) (*Invitation, error) {
+	if _, err := roleRank(role); err != nil {
+		return nil, err
+	}
+
+	u, err := findUserByLogin(ctx, c, email)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for existing user: %w", err)
+	}
+	if u != nil {
+		return nil, fmt.Errorf("a user with login %s already exists", gofastly.ToValue(u.Login))
+	}
+
+	inv, err := findInvitationByEmail(ctx, c, email)
+	if err != nil {
+		return nil, fmt.Errorf("error checking for existing invitation: %w", err)
+	}
+	if inv != nil {
+		return nil, fmt.Errorf("an invitation for %s is already pending: %s", inv.Email, inv.ID)
+	}
+
+	customerID, err := currentCustomerID(ctx, c.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := createInvitation(ctx, c, email, role, customerID)
+	if err != nil {
+		return nil, err
+	}
+	return flattenInvitation(resp.Data), nil
+}
+
+// RevokeInvitation deletes an invitation, given its ID or the email address
+// it was sent to. An email address only matches a pending invitation, like it
+// does for fastly_user.
+func (c *APIClient) RevokeInvitation(ctx context.Context, idOrEmail string) (*Invitation, error) {
+	var inv *Invitation
+	var err error
+	if strings.Contains(idOrEmail, "@") {
+		inv, err = findInvitationByEmail(ctx, c, idOrEmail)
+		if err == nil && inv == nil {
+			err = fmt.Errorf("no pending invitation found for %s", idOrEmail)
+		}
+	} else {
+		inv, err = getInvitation(ctx, c, idOrEmail)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deleteInvitation(ctx, c, inv.ID); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// Errors returned by SetUserLocked before it attempts the update, so callers
+// can tell a failed lookup and a missing user from a failed update.
+var (
+	errUserLookup   = errors.New("error looking up user")
+	errUserNotFound = errors.New("user not found")
+)
+
+// SetUserLocked locks or unlocks the user with the given login, like the
+// fastly_lock_user action does.
+func (c *APIClient) SetUserLocked(ctx context.Context, login string, locked bool) (*gofastly.User, error) {
+	u, err := findUserByLogin(ctx, c, login)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUserLookup, err)
+	}
+	if u == nil {
+		return nil, fmt.Errorf("%w: there is no user with login %s; to stop a pending invitation from being accepted, revoke it instead", errUserNotFound, login)
+	}
+
+	if err := setUserLocked(ctx, c.conn, gofastly.ToValue(u.UserID), locked); err != nil {
+		return nil, err
+	}
+
+	u.Locked = gofastly.ToPointer(locked)
+	return u, nil
+}
+
+// ListTokens returns the API tokens of all users of the account.
+func (c *APIClient) ListTokens(ctx context.Context) ([]*gofastly.Token, error) {
+	customerID, err := currentCustomerID(ctx, c.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.conn.ListCustomerTokens(ctx, &gofastly.ListCustomerTokensInput{
+		CustomerID: customerID,
+	})
+}
+
+// matchPendingInvitations returns the invitations that are pending and were
+// sent to email, comparing addresses with the given rules. It is shared by
+// fastly_user and the methods above, so both agree on which invitation counts
+// as existing.
+func matchPendingInvitations(rules LoginNormalization, invitations []*Invitation, email string) []*Invitation {
+	var matches []*Invitation
+	for _, inv := range invitations {
+		if inv.Status() == "pending" && rules.Equal(inv.Email, email) {
+			matches = append(matches, inv)
+		}
+	}
+	return matches
+}
+
+// invitationIDs returns the IDs of invitations as a comma-separated list.
+func invitationIDs(invitations []*Invitation) string {
+	ids := make([]string, 0, len(invitations))
+	for _, inv := range invitations {
+		ids = append(ids, inv.ID)
+	}
+	return strings.Join(ids, ", ")
+}
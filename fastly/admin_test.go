@@ -0,0 +1,37 @@
+package fastly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchPendingInvitations(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	invitations := []*Invitation{
+		{ID: "expired", Email: "jane@example.com", StatusCode: invitationStatusActive, ExpiresAt: past},
+		{ID: "accepted", Email: "jane@example.com", StatusCode: invitationStatusInactive, ExpiresAt: future},
+		{ID: "pending", Email: "Jane@Example.com", StatusCode: invitationStatusActive, ExpiresAt: future},
+		{ID: "plus", Email: "jane+fastly@example.com", StatusCode: invitationStatusActive, ExpiresAt: future},
+		{ID: "other", Email: "john@example.com", StatusCode: invitationStatusActive, ExpiresAt: future},
+	}
+
+	cases := []struct {
+		name  string
+		rules LoginNormalization
+		email string
+		want  string
+	}{
+		{"pending only", DefaultLoginNormalization, "jane@example.com", "pending"},
+		{"case sensitive", LoginNormalization{}, "jane@example.com", ""},
+		{"several matches", LoginNormalization{CaseInsensitive: true, IgnorePlusSuffix: true}, "jane@example.com", "pending, plus"},
+		{"no invitation", DefaultLoginNormalization, "joe@example.com", ""},
+	}
+
+	for _, tc := range cases {
+		if got := invitationIDs(matchPendingInvitations(tc.rules, invitations, tc.email)); got != tc.want {
+			t.Errorf("%s: matchPendingInvitations(%q) = %q, want %q", tc.name, tc.email, got, tc.want)
+		}
+	}
+}
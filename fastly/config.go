@@ -23,12 +23,16 @@ type Config struct {
 	NoAuth     bool
 	UserAgent  string
 	Context    context.Context
+
+	LoginNormalization LoginNormalization
 }
 
 // APIClient is a HTTP API Client.
 type APIClient struct {
 	conn   *gofastly.Client
 	apiKey string
+
+	loginNormalization LoginNormalization
 }
 
 // Client returns a FastlyClient.
@@ -99,5 +103,6 @@ func (c *Config) Client() (*APIClient, diag.Diagnostics) {
 
 	client.conn = fastlyClient
 	client.apiKey = c.APIKey
+	client.loginNormalization = c.LoginNormalization
 	return &client, nil
 }
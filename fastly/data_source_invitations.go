@@ -2,9 +2,11 @@ package fastly
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	gofastly "github.com/fastly/go-fastly/v12/fastly"
 )
@@ -13,10 +15,33 @@ func dataSourceFastlyInvitations() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceFastlyInvitationsRead,
 		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include invitations sent to this email address, compared using the provider's login normalization rules",
+			},
+			"role": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only include invitations for this role",
+				ValidateDiagFunc: validateUserRole(),
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only include invitations with this status. Can be `pending`, `inactive`, `expired` or `unknown`",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"pending", "inactive", "expired", "unknown"}, false)),
+			},
+			"older_than": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only include invitations created longer ago than this duration, e.g. `336h` for two weeks",
+				ValidateDiagFunc: validateDuration(),
+			},
 			"invitations": {
 				Type:        schema.TypeList,
 				Computed:    true,
-				Description: "List of all pending invitations for the current customer account",
+				Description: "List of the matching invitations for the current customer account",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -34,11 +59,42 @@ func dataSourceFastlyInvitations() *schema.Resource {
 							Computed:    true,
 							Description: "The role assigned to the invitee",
 						},
+						"roles": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The roles assigned to the invitee, when the invitation uses multiple roles",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"limit_services": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the invitee will have limited access to services",
+						},
 						"status_code": {
 							Type:        schema.TypeInt,
 							Computed:    true,
 							Description: "The status code of the invitation",
 						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The human-readable status of the invitation: `pending`, `inactive`, `expired` or `unknown`",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the invitation was created",
+						},
+						"expires_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the invitation expires, if reported by the API",
+						},
+						"invited_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user who sent the invitation, if reported by the API",
+						},
 					},
 				},
 			},
@@ -64,14 +120,47 @@ func dataSourceFastlyInvitationsRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	result := make([]map[string]any, len(invitations.Data))
-	for i, inv := range invitations.Data {
-		result[i] = map[string]any{
-			"id":          inv.ID,
-			"email":       inv.Attributes.Email,
-			"role":        inv.Attributes.Role,
-			"status_code": inv.Attributes.StatusCode,
+	var olderThan time.Duration
+	if v := d.Get("older_than").(string); v != "" {
+		olderThan, err = time.ParseDuration(v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	email := d.Get("email").(string)
+	role := d.Get("role").(string)
+	status := d.Get("status").(string)
+
+	result := make([]map[string]any, 0, len(invitations.Data))
+	for _, data := range invitations.Data {
+		inv := flattenInvitation(data)
+
+		if email != "" && !client.loginNormalization.Equal(inv.Email, email) {
+			continue
+		}
+		if role != "" && inv.Role != role {
+			continue
+		}
+		if status != "" && inv.Status() != status {
+			continue
+		}
+		if olderThan > 0 && inv.Age() < olderThan {
+			continue
 		}
+
+		result = append(result, map[string]any{
+			"id":             inv.ID,
+			"email":          inv.Email,
+			"role":           inv.Role,
+			"roles":          inv.Roles,
+			"limit_services": inv.LimitServices,
+			"status_code":    inv.StatusCode,
+			"status":         inv.Status(),
+			"created_at":     inv.CreatedAt,
+			"expires_at":     inv.ExpiresAt,
+			"invited_by":     inv.InvitedBy,
+		})
 	}
 
 	if err := d.Set("invitations", result); err != nil {
@@ -83,6 +172,3 @@ func dataSourceFastlyInvitationsRead(ctx context.Context, d *schema.ResourceData
 
 	return nil
 }
-
-
-
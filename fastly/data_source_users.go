@@ -2,9 +2,14 @@ package fastly
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	gofastly "github.com/fastly/go-fastly/v12/fastly"
 )
@@ -13,10 +18,50 @@ func dataSourceFastlyUsers() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceFastlyUsersRead,
 		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only include users with this role",
+				ValidateDiagFunc: validateUserRole(),
+			},
+			"locked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only include users whose account lock status matches this value",
+			},
+			"two_factor_auth_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only include users whose two-factor authentication status matches this value",
+			},
+			"limit_services": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only include users whose limited service access matches this value",
+			},
+			"login_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only include users whose login matches this regular expression",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"email_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include users whose login belongs to this email domain (case-insensitive)",
+			},
+			"users_by_login": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of login to user ID for the matching users",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"users": {
 				Type:        schema.TypeList,
 				Computed:    true,
-				Description: "List of all users for the current customer account",
+				Description: "List of the matching users for the current customer account, sorted by login",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -95,9 +140,23 @@ func dataSourceFastlyUsersRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	result := make([]map[string]any, len(users))
-	for i, u := range users {
-		result[i] = map[string]any{
+	filter, err := expandUsersFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return gofastly.ToValue(users[i].Login) < gofastly.ToValue(users[j].Login)
+	})
+
+	result := make([]map[string]any, 0, len(users))
+	byLogin := make(map[string]any, len(users))
+	for _, u := range users {
+		if !filter.matches(u) {
+			continue
+		}
+
+		user := map[string]any{
 			"id":                      gofastly.ToValue(u.UserID),
 			"login":                   gofastly.ToValue(u.Login),
 			"name":                    gofastly.ToValue(u.Name),
@@ -109,16 +168,22 @@ func dataSourceFastlyUsersRead(ctx context.Context, d *schema.ResourceData, meta
 		}
 
 		if u.CreatedAt != nil {
-			result[i]["created_at"] = u.CreatedAt.String()
+			user["created_at"] = u.CreatedAt.String()
 		}
 		if u.UpdatedAt != nil {
-			result[i]["updated_at"] = u.UpdatedAt.String()
+			user["updated_at"] = u.UpdatedAt.String()
 		}
+
+		result = append(result, user)
+		byLogin[gofastly.ToValue(u.Login)] = gofastly.ToValue(u.UserID)
 	}
 
 	if err := d.Set("users", result); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("users_by_login", byLogin); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Use customer ID as the data source ID
 	d.SetId(customerID)
@@ -126,5 +191,57 @@ func dataSourceFastlyUsersRead(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// usersFilter holds the optional filters of the fastly_users data source.
+// Nil pointers and empty strings mean the filter is not set.
+type usersFilter struct {
+	role                 string
+	locked               *bool
+	twoFactorAuthEnabled *bool
+	limitServices        *bool
+	loginRegex           *regexp.Regexp
+	emailDomain          string
+}
+
+func expandUsersFilter(d *schema.ResourceData) (*usersFilter, error) {
+	filter := &usersFilter{
+		role:                 d.Get("role").(string),
+		locked:               getOptionalBool(d, "locked"),
+		twoFactorAuthEnabled: getOptionalBool(d, "two_factor_auth_enabled"),
+		limitServices:        getOptionalBool(d, "limit_services"),
+		emailDomain:          strings.ToLower(strings.TrimPrefix(d.Get("email_domain").(string), "@")),
+	}
 
+	if v := d.Get("login_regex").(string); v != "" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid login_regex: %w", err)
+		}
+		filter.loginRegex = re
+	}
+
+	return filter, nil
+}
 
+func (f *usersFilter) matches(u *gofastly.User) bool {
+	login := gofastly.ToValue(u.Login)
+
+	if f.role != "" && gofastly.ToValue(u.Role) != f.role {
+		return false
+	}
+	if f.locked != nil && gofastly.ToValue(u.Locked) != *f.locked {
+		return false
+	}
+	if f.twoFactorAuthEnabled != nil && gofastly.ToValue(u.TwoFactorAuthEnabled) != *f.twoFactorAuthEnabled {
+		return false
+	}
+	if f.limitServices != nil && gofastly.ToValue(u.LimitServices) != *f.limitServices {
+		return false
+	}
+	if f.loginRegex != nil && !f.loginRegex.MatchString(login) {
+		return false
+	}
+	if f.emailDomain != "" && emailDomain(login) != f.emailDomain {
+		return false
+	}
+	return true
+}
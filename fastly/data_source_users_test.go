@@ -1,9 +1,12 @@
 package fastly
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
 )
 
 func TestAccFastlyDataSourceUsers_basic(t *testing.T) {
@@ -24,6 +27,64 @@ func TestAccFastlyDataSourceUsers_basic(t *testing.T) {
 	})
 }
 
+func TestAccFastlyDataSourceUsers_filtered(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFastlyDataSourceUsersFilteredConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.fastly_users.superusers", "users.#"),
+					resource.TestCheckResourceAttrSet("data.fastly_users.superusers", "users_by_login.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestUsersFilterMatches(t *testing.T) {
+	user := &gofastly.User{
+		Login:                gofastly.ToPointer("Jane.Doe@Example.com"),
+		Role:                 gofastly.ToPointer("superuser"),
+		Locked:               gofastly.ToPointer(false),
+		TwoFactorAuthEnabled: gofastly.ToPointer(true),
+		LimitServices:        gofastly.ToPointer(false),
+	}
+
+	cases := []struct {
+		name   string
+		filter usersFilter
+		want   bool
+	}{
+		{"no filters", usersFilter{}, true},
+		{"role match", usersFilter{role: "superuser"}, true},
+		{"role mismatch", usersFilter{role: "engineer"}, false},
+		{"locked mismatch", usersFilter{locked: gofastly.ToPointer(true)}, false},
+		{"2fa match", usersFilter{twoFactorAuthEnabled: gofastly.ToPointer(true)}, true},
+		{"limit_services mismatch", usersFilter{limitServices: gofastly.ToPointer(true)}, false},
+		{"regex match", usersFilter{loginRegex: regexp.MustCompile(`^Jane\.`)}, true},
+		{"regex mismatch", usersFilter{loginRegex: regexp.MustCompile(`^john`)}, false},
+		{"domain match", usersFilter{emailDomain: "example.com"}, true},
+		{"domain mismatch", usersFilter{emailDomain: "example.org"}, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.filter.matches(user); got != tc.want {
+			t.Errorf("%s: got %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
+
 const testAccFastlyDataSourceUsersConfig = `
 data "fastly_users" "test" {}
 `
+
+const testAccFastlyDataSourceUsersFilteredConfig = `
+data "fastly_users" "superusers" {
+  role   = "superuser"
+  locked = false
+}
+`
@@ -0,0 +1,305 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+const (
+	// apiTokenDefaultTTL is how long a token lives when ttl is not set.
+	apiTokenDefaultTTL = time.Hour
+
+	// apiTokenPrivateKey is the private data key holding the token to revoke
+	// on close.
+	apiTokenPrivateKey = "token"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &apiTokenEphemeralResource{}
+)
+
+// apiTokenEphemeralResource mints a short-lived API token on open and revokes
+// it on close, so the token never ends up in the state file.
+type apiTokenEphemeralResource struct {
+	client *APIClient
+}
+
+type apiTokenModel struct {
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Name        types.String `tfsdk:"name"`
+	Scopes      types.List   `tfsdk:"scopes"`
+	Services    types.List   `tfsdk:"services"`
+	TTL         types.String `tfsdk:"ttl"`
+	ID          types.String `tfsdk:"id"`
+	AccessToken types.String `tfsdk:"access_token"`
+	UserID      types.String `tfsdk:"user_id"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// apiTokenPrivateData is kept by Terraform between open and close.
+type apiTokenPrivateData struct {
+	TokenID     string `json:"token_id"`
+	AccessToken string `json:"access_token"`
+}
+
+func newAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &apiTokenEphemeralResource{}
+}
+
+func (r *apiTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *apiTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short-lived Fastly API token for a user, typically a service account, and revokes it once Terraform is done with it",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "The login of the user the token is created for",
+			},
+			"password": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The password of the user the token is created for",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the token. Default: `terraform-ephemeral`",
+			},
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The scopes of the token. Can be `global`, `global:read`, `purge_select` or `purge_all`. Default: `[\"global\"]`",
+			},
+			"services": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Limit the token to these service IDs. By default the token has access to all services",
+			},
+			"ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long the token is valid for, e.g. `30m`. The token is revoked earlier when Terraform closes the resource. Default: `1h`",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the token",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API token",
+			},
+			"user_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the user the token belongs to",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the token expires, in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func (r *apiTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = frameworkAPIClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *apiTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data apiTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.TTL.IsNull() && !data.TTL.IsUnknown() {
+		if _, err := parseTokenTTL(data.TTL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid ttl", err.Error())
+		}
+	}
+
+	if !data.Scopes.IsNull() && !data.Scopes.IsUnknown() {
+		var scopes []types.String
+		resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+		for _, s := range scopes {
+			if s.IsUnknown() {
+				return
+			}
+		}
+		if _, err := tokenScope(stringValues(scopes)); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("scopes"), "Invalid scopes", err.Error())
+		}
+	}
+}
+
+func (r *apiTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if !checkConfiguredClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data apiTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl, err := parseTokenTTL(data.TTL.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid ttl", err.Error())
+		return
+	}
+
+	var scopes, services []types.String
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+	resp.Diagnostics.Append(data.Services.ElementsAs(ctx, &services, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scope, err := tokenScope(stringValues(scopes))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("scopes"), "Invalid scopes", err.Error())
+		return
+	}
+
+	name := data.Name.ValueString()
+	if name == "" {
+		name = "terraform-ephemeral"
+	}
+
+	expiresAt := time.Now().UTC().Add(ttl)
+	input := &gofastly.CreateTokenInput{
+		Username:  gofastly.ToPointer(data.Username.ValueString()),
+		Password:  gofastly.ToPointer(data.Password.ValueString()),
+		Name:      gofastly.ToPointer(name),
+		Scope:     gofastly.ToPointer(scope),
+		Services:  stringValues(services),
+		ExpiresAt: &expiresAt,
+	}
+
+	token, err := r.client.conn.CreateToken(ctx, input)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	tokenID := gofastly.ToValue(token.TokenID)
+	accessToken := gofastly.ToValue(token.AccessToken)
+
+	tflog.Debug(ctx, "Created ephemeral API token", map[string]any{"token_id": tokenID, "username": data.Username.ValueString()})
+
+	data.ID = types.StringValue(tokenID)
+	data.AccessToken = types.StringValue(accessToken)
+	data.UserID = types.StringValue(gofastly.ToValue(token.UserID))
+	data.ExpiresAt = types.StringNull()
+	if token.ExpiresAt != nil {
+		data.ExpiresAt = types.StringValue(token.ExpiresAt.Format(time.RFC3339))
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	private, err := json.Marshal(apiTokenPrivateData{TokenID: tokenID, AccessToken: accessToken})
+	if err != nil {
+		resp.Diagnostics.AddError("Error storing API token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiTokenPrivateKey, private)...)
+}
+
+func (r *apiTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if !checkConfiguredClient(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	raw, diags := req.Private.GetKey(ctx, apiTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var private apiTokenPrivateData
+	if err := json.Unmarshal(raw, &private); err != nil {
+		resp.Diagnostics.AddError("Error reading API token", err.Error())
+		return
+	}
+
+	// The token revokes itself, so this works whatever permissions the
+	// provider's own API key has over the token's user.
+	conn, err := gofastly.NewClientForEndpoint(private.AccessToken, r.client.conn.Address)
+	if err != nil {
+		resp.Diagnostics.AddError("Error revoking API token", err.Error())
+		return
+	}
+	conn.HTTPClient = r.client.conn.HTTPClient
+
+	if err := conn.DeleteTokenSelf(ctx); err != nil {
+		// An expired token can no longer authenticate, and is no longer
+		// usable anyway.
+		if httpErr, ok := err.(*gofastly.HTTPError); ok && httpErr.StatusCode == http.StatusUnauthorized {
+			tflog.Debug(ctx, "Ephemeral API token already expired", map[string]any{"token_id": private.TokenID})
+			return
+		}
+		resp.Diagnostics.AddError("Error revoking API token", fmt.Sprintf("token %s: %s", private.TokenID, err))
+		return
+	}
+
+	tflog.Debug(ctx, "Revoked ephemeral API token", map[string]any{"token_id": private.TokenID})
+}
+
+// parseTokenTTL parses the ttl argument, defaulting to apiTokenDefaultTTL.
+func parseTokenTTL(v string) (time.Duration, error) {
+	if v == "" {
+		return apiTokenDefaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", v, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", v)
+	}
+	return ttl, nil
+}
+
+// tokenScope joins scopes into the space-delimited form the API expects,
+// defaulting to the global scope.
+func tokenScope(scopes []string) (gofastly.TokenScope, error) {
+	if len(scopes) == 0 {
+		return gofastly.GlobalScope, nil
+	}
+	for _, s := range scopes {
+		switch gofastly.TokenScope(s) {
+		case gofastly.GlobalScope, gofastly.GlobalReadScope, gofastly.PurgeSelectScope, gofastly.PurgeAllScope:
+		default:
+			return "", fmt.Errorf("unknown scope %q, expected one of global, global:read, purge_select or purge_all", s)
+		}
+	}
+	return gofastly.TokenScope(strings.Join(scopes, " ")), nil
+}
+
+// stringValues converts framework strings to plain strings.
+func stringValues(values []types.String) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.ValueString())
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package fastly
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+func TestParseTokenTTL(t *testing.T) {
+	cases := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: apiTokenDefaultTTL},
+		{value: "30m", want: 30 * time.Minute},
+		{value: "0s", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := parseTokenTTL(c.value)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseTokenTTL(%q): unexpected error: %v", c.value, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseTokenTTL(%q) = %s, want %s", c.value, got, c.want)
+		}
+	}
+}
+
+func TestTokenScope(t *testing.T) {
+	cases := []struct {
+		scopes  []string
+		want    gofastly.TokenScope
+		wantErr bool
+	}{
+		{scopes: nil, want: gofastly.GlobalScope},
+		{scopes: []string{"global:read"}, want: gofastly.GlobalReadScope},
+		{scopes: []string{"purge_select", "purge_all"}, want: "purge_select purge_all"},
+		{scopes: []string{"global", "admin"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := tokenScope(c.scopes)
+		if (err != nil) != c.wantErr {
+			t.Errorf("tokenScope(%v): unexpected error: %v", c.scopes, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("tokenScope(%v) = %q, want %q", c.scopes, got, c.want)
+		}
+	}
+}
+
+func TestAPITokenEphemeralResourceUnconfigured(t *testing.T) {
+	r := &apiTokenEphemeralResource{}
+
+	var resp ephemeral.OpenResponse
+	r.Open(context.Background(), ephemeral.OpenRequest{}, &resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unconfigured provider" {
+		t.Errorf("expected an unconfigured provider error, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,287 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+// Output formats of Export.
+const (
+	ExportFormatHCL  = "hcl"
+	ExportFormatJSON = "json"
+)
+
+// exportRosterResource is the name of the single fastly_user resource written
+// with ExportOptions.Roster, and exportRosterLocal the local value holding the
+// roster map.
+const (
+	exportRosterResource = "roster"
+	exportRosterLocal    = "fastly_users"
+)
+
+// ExportOptions controls what Export writes.
+type ExportOptions struct {
+	// Format is ExportFormatHCL or ExportFormatJSON.
+	Format string
+	// IncludeInvitations also exports pending invitations.
+	IncludeInvitations bool
+	// Roster writes a map of users and a single fastly_user resource using
+	// for_each, instead of one resource per user.
+	Roster bool
+}
+
+// exportUser is a user or pending invitation to export.
+type exportUser struct {
+	ResourceName string
+	Login        string
+	Name         string
+	Role         string
+	Locked       bool
+	Pending      bool
+}
+
+// ImportID returns the import ID of the user.
+func (u exportUser) ImportID() string {
+	return "login/" + u.Login
+}
+
+// Export writes fastly_user configuration and import blocks for the users,
+// and optionally pending invitations, of the account that owns the API key.
+func Export(ctx context.Context, client *APIClient, w io.Writer, opts ExportOptions) error {
+	if opts.Format != ExportFormatHCL && opts.Format != ExportFormatJSON {
+		return fmt.Errorf("unknown format %q, expected %s or %s", opts.Format, ExportFormatHCL, ExportFormatJSON)
+	}
+
+	_, users, invitations, err := listUsersAndInvitations(ctx, client, opts.IncludeInvitations)
+	if err != nil {
+		return err
+	}
+
+	entries := exportUsers(users, invitations)
+
+	if opts.Format == ExportFormatJSON {
+		return writeExportJSON(w, entries, opts.Roster)
+	}
+	return writeExportHCL(w, entries, opts.Roster)
+}
+
+// exportUsers converts users and invitations, giving each a unique resource
+// name.
+func exportUsers(users []*gofastly.User, invitations []*Invitation) []exportUser {
+	entries := make([]exportUser, 0, len(users)+len(invitations))
+	for _, u := range users {
+		entries = append(entries, exportUser{
+			Login:  gofastly.ToValue(u.Login),
+			Name:   gofastly.ToValue(u.Name),
+			Role:   gofastly.ToValue(u.Role),
+			Locked: gofastly.ToValue(u.Locked),
+		})
+	}
+	for _, inv := range invitations {
+		entries = append(entries, exportUser{
+			Login:   inv.Email,
+			Role:    inv.Role,
+			Pending: true,
+		})
+	}
+
+	used := make(map[string]bool, len(entries))
+	for i := range entries {
+		base := exportResourceName(entries[i].Login)
+		name := base
+		// A suffixed name can itself be derived from another login, so
+		// keep counting until the name is unused.
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[name] = true
+		entries[i].ResourceName = name
+	}
+	return entries
+}
+
+var exportInvalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// exportResourceName derives a resource name from a login, e.g.
+// jane.doe@example.com becomes jane_doe_example_com.
+func exportResourceName(login string) string {
+	name := exportInvalidNameChars.ReplaceAllString(strings.ToLower(login), "_")
+	name = strings.Trim(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "user_" + name
+	}
+	return name
+}
+
+const exportPendingComment = "Invitation pending: name is not set, as the invitee chooses it when accepting the invitation"
+
+func writeExportHCL(w io.Writer, entries []exportUser, roster bool) error {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	if roster {
+		users := make(map[string]cty.Value, len(entries))
+		for _, u := range entries {
+			attrs := map[string]cty.Value{
+				"role": cty.StringVal(u.Role),
+			}
+			if !u.Pending {
+				attrs["name"] = cty.StringVal(u.Name)
+			}
+			if u.Locked {
+				attrs["locked"] = cty.True
+			}
+			users[u.Login] = cty.ObjectVal(attrs)
+		}
+
+		locals := body.AppendNewBlock("locals", nil).Body()
+		if len(users) == 0 {
+			locals.SetAttributeValue(exportRosterLocal, cty.EmptyObjectVal)
+		} else {
+			locals.SetAttributeValue(exportRosterLocal, cty.ObjectVal(users))
+		}
+		body.AppendNewline()
+
+		res := body.AppendNewBlock("resource", []string{"fastly_user", exportRosterResource}).Body()
+		res.SetAttributeTraversal("for_each", hcl.Traversal{hcl.TraverseRoot{Name: "local"}, hcl.TraverseAttr{Name: exportRosterLocal}})
+		res.AppendNewline()
+		res.SetAttributeTraversal("login", hcl.Traversal{hcl.TraverseRoot{Name: "each"}, hcl.TraverseAttr{Name: "key"}})
+		res.SetAttributeRaw("name", hclwrite.TokensForFunctionCall("try",
+			hclwrite.TokensForTraversal(hcl.Traversal{hcl.TraverseRoot{Name: "each"}, hcl.TraverseAttr{Name: "value"}, hcl.TraverseAttr{Name: "name"}}),
+			hclwrite.TokensForIdentifier("null"),
+		))
+		res.SetAttributeTraversal("role", hcl.Traversal{hcl.TraverseRoot{Name: "each"}, hcl.TraverseAttr{Name: "value"}, hcl.TraverseAttr{Name: "role"}})
+		res.SetAttributeRaw("locked", hclwrite.TokensForFunctionCall("try",
+			hclwrite.TokensForTraversal(hcl.Traversal{hcl.TraverseRoot{Name: "each"}, hcl.TraverseAttr{Name: "value"}, hcl.TraverseAttr{Name: "locked"}}),
+			hclwrite.TokensForValue(cty.False),
+		))
+
+		for _, u := range entries {
+			body.AppendNewline()
+			imp := body.AppendNewBlock("import", nil).Body()
+			imp.SetAttributeTraversal("to", hcl.Traversal{
+				hcl.TraverseRoot{Name: "fastly_user"},
+				hcl.TraverseAttr{Name: exportRosterResource},
+				hcl.TraverseIndex{Key: cty.StringVal(u.Login)},
+			})
+			imp.SetAttributeValue("id", cty.StringVal(u.ImportID()))
+		}
+	} else {
+		for i, u := range entries {
+			if i > 0 {
+				body.AppendNewline()
+			}
+			if u.Pending {
+				body.AppendUnstructuredTokens(hclwrite.Tokens{{
+					Type:  hclsyntax.TokenComment,
+					Bytes: []byte("# " + exportPendingComment + "\n"),
+				}})
+			}
+
+			res := body.AppendNewBlock("resource", []string{"fastly_user", u.ResourceName}).Body()
+			res.SetAttributeValue("login", cty.StringVal(u.Login))
+			if !u.Pending {
+				res.SetAttributeValue("name", cty.StringVal(u.Name))
+			}
+			res.SetAttributeValue("role", cty.StringVal(u.Role))
+			if u.Locked {
+				res.SetAttributeValue("locked", cty.True)
+			}
+
+			body.AppendNewline()
+			imp := body.AppendNewBlock("import", nil).Body()
+			imp.SetAttributeTraversal("to", hcl.Traversal{
+				hcl.TraverseRoot{Name: "fastly_user"},
+				hcl.TraverseAttr{Name: u.ResourceName},
+			})
+			imp.SetAttributeValue("id", cty.StringVal(u.ImportID()))
+		}
+	}
+
+	_, err := f.WriteTo(w)
+	return err
+}
+
+// writeExportJSON writes the configuration in Terraform's JSON syntax.
+func writeExportJSON(w io.Writer, entries []exportUser, roster bool) error {
+	resources := make(map[string]any)
+	imports := make([]map[string]string, 0, len(entries))
+	config := map[string]any{
+		"resource": map[string]any{"fastly_user": resources},
+	}
+
+	if roster {
+		users := make(map[string]any, len(entries))
+		for _, u := range entries {
+			attrs := map[string]any{
+				"role": exportJSONString(u.Role),
+			}
+			if !u.Pending {
+				attrs["name"] = exportJSONString(u.Name)
+			}
+			if u.Locked {
+				attrs["locked"] = true
+			}
+			users[exportJSONString(u.Login)] = attrs
+
+			imports = append(imports, map[string]string{
+				"to": fmt.Sprintf("fastly_user.%s[%q]", exportRosterResource, u.Login),
+				"id": exportJSONString(u.ImportID()),
+			})
+		}
+
+		config["locals"] = map[string]any{exportRosterLocal: users}
+		resources[exportRosterResource] = map[string]any{
+			"for_each": "${local." + exportRosterLocal + "}",
+			"login":    "${each.key}",
+			"name":     "${try(each.value.name, null)}",
+			"role":     "${each.value.role}",
+			"locked":   "${try(each.value.locked, false)}",
+		}
+	} else {
+		for _, u := range entries {
+			attrs := map[string]any{
+				"login": exportJSONString(u.Login),
+				"role":  exportJSONString(u.Role),
+			}
+			if u.Pending {
+				attrs["//"] = exportPendingComment
+			} else {
+				attrs["name"] = exportJSONString(u.Name)
+			}
+			if u.Locked {
+				attrs["locked"] = true
+			}
+			resources[u.ResourceName] = attrs
+
+			imports = append(imports, map[string]string{
+				"to": "fastly_user." + u.ResourceName,
+				"id": exportJSONString(u.ImportID()),
+			})
+		}
+	}
+	config["import"] = imports
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(config)
+}
+
+// exportJSONString escapes template sequences, as strings in Terraform's JSON
+// syntax are templates.
+func exportJSONString(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
@@ -0,0 +1,152 @@
+package fastly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+func TestExportResourceName(t *testing.T) {
+	cases := map[string]string{
+		"jane.doe@example.com":  "jane_doe_example_com",
+		"Jane+Ops@Example.com":  "jane_ops_example_com",
+		"1st-admin@example.com": "user_1st-admin_example_com",
+		"@@@":                   "user_",
+	}
+	for login, want := range cases {
+		if got := exportResourceName(login); got != want {
+			t.Errorf("exportResourceName(%q) = %q, want %q", login, got, want)
+		}
+	}
+}
+
+func TestExportUsers(t *testing.T) {
+	users := []*gofastly.User{
+		{Login: gofastly.ToPointer("jane@example.com"), Name: gofastly.ToPointer("Jane"), Role: gofastly.ToPointer("engineer")},
+		{Login: gofastly.ToPointer("Jane@Example.com"), Name: gofastly.ToPointer("Jane 2"), Role: gofastly.ToPointer("user"), Locked: gofastly.ToPointer(true)},
+		// Derives the name the previous login got suffixed to
+		{Login: gofastly.ToPointer("jane@example.com.2"), Name: gofastly.ToPointer("Jane 3"), Role: gofastly.ToPointer("user")},
+	}
+	invitations := []*Invitation{
+		{Email: "bob@example.com", Role: "billing"},
+	}
+
+	entries := exportUsers(users, invitations)
+	want := []exportUser{
+		{ResourceName: "jane_example_com", Login: "jane@example.com", Name: "Jane", Role: "engineer"},
+		{ResourceName: "jane_example_com_2", Login: "Jane@Example.com", Name: "Jane 2", Role: "user", Locked: true},
+		{ResourceName: "jane_example_com_2_2", Login: "jane@example.com.2", Name: "Jane 3", Role: "user"},
+		{ResourceName: "bob_example_com", Login: "bob@example.com", Role: "billing", Pending: true},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	entries := []exportUser{
+		{ResourceName: "jane_example_com", Login: "jane@example.com", Name: "Jane ${x}", Role: "engineer", Locked: true},
+		{ResourceName: "bob_example_com", Login: "bob@example.com", Role: "billing", Pending: true},
+	}
+
+	for _, roster := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := writeExportHCL(&buf, entries, roster); err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := hclsyntax.ParseConfig(buf.Bytes(), "export.tf", hcl.InitialPos); diags.HasErrors() {
+			t.Errorf("roster=%t: invalid HCL: %s\n%s", roster, diags, buf.String())
+		}
+		if !strings.Contains(buf.String(), `id = "login/bob@example.com"`) {
+			t.Errorf("roster=%t: missing import block:\n%s", roster, buf.String())
+		}
+		if strings.Contains(buf.String(), `name = ""`) {
+			t.Errorf("roster=%t: empty name exported for pending invitation:\n%s", roster, buf.String())
+		}
+
+		buf.Reset()
+		if err := writeExportJSON(&buf, entries, roster); err != nil {
+			t.Fatal(err)
+		}
+		var config map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &config); err != nil {
+			t.Errorf("roster=%t: invalid JSON: %s\n%s", roster, err, buf.String())
+		}
+		if !strings.Contains(buf.String(), `Jane $${x}`) {
+			t.Errorf("roster=%t: template sequence not escaped:\n%s", roster, buf.String())
+		}
+		if strings.Contains(buf.String(), `"name": ""`) {
+			t.Errorf("roster=%t: empty name exported for pending invitation:\n%s", roster, buf.String())
+		}
+	}
+}
+
+// TestExportPendingInvitationAccepted checks that the configuration exported
+// for a pending invitation does not change the name the invitee chose once
+// they accept the invitation.
+func TestExportPendingInvitationAccepted(t *testing.T) {
+	var buf bytes.Buffer
+	entries := exportUsers(nil, []*Invitation{{Email: "bob@example.com", Role: "billing"}})
+	if err := writeExportHCL(&buf, entries, false); err != nil {
+		t.Fatal(err)
+	}
+
+	file, diags := hclsyntax.ParseConfig(buf.Bytes(), "export.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	config := make(map[string]any)
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "resource" {
+			continue
+		}
+		for name, attr := range block.Body.Attributes {
+			v, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			config[name] = v.AsString()
+		}
+	}
+
+	// State after importing the invitation and the invitee accepting it
+	state := &terraform.InstanceState{
+		ID: "bob@example.com",
+		Attributes: map[string]string{
+			"id":                                "bob@example.com",
+			"login":                             "bob@example.com",
+			"name":                              "Bob Builder",
+			"role":                              "billing",
+			"user_id":                           "user123",
+			"invitation_id":                     "",
+			"on_existing":                       onExistingAdopt,
+			"on_destroy":                        onDestroyDelete,
+			"deletion_protection":               "false",
+			"wait_for_acceptance":               "false",
+			"reissue_invitation_on_role_change": "true",
+		},
+	}
+
+	diff, err := resourceUser(nil).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil {
+		if attr, ok := diff.Attributes["name"]; ok {
+			t.Errorf("unexpected name change from %q to %q", attr.Old, attr.New)
+		}
+	}
+}
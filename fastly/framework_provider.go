@@ -0,0 +1,228 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+// NOTE: The framework provider is served next to the SDKv2 provider through
+// terraform-plugin-mux (see main.go). Existing resources and data sources stay
+// in Provider(); anything that needs the framework (ephemeral resources,
+// provider functions, actions, list resources) is registered here.
+//
+// Both providers must declare exactly the same provider schema, otherwise the
+// mux server refuses to start.
+
+var (
+	_ provider.Provider                       = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithActions            = &frameworkProvider{}
+	_ provider.ProviderWithListResources      = &frameworkProvider{}
+)
+
+// frameworkProvider is the terraform-plugin-framework half of the provider.
+type frameworkProvider struct {
+	version string
+}
+
+// frameworkProviderModel maps the provider configuration. It mirrors the
+// SDKv2 schema in Provider().
+type frameworkProviderModel struct {
+	APIKey                types.String `tfsdk:"api_key"`
+	BaseURL               types.String `tfsdk:"base_url"`
+	ForceHTTP2            types.Bool   `tfsdk:"force_http2"`
+	LoginCaseInsensitive  types.Bool   `tfsdk:"login_case_insensitive"`
+	LoginIgnorePlusSuffix types.Bool   `tfsdk:"login_ignore_plus_suffix"`
+}
+
+// NewFrameworkProvider returns a function creating the plugin-framework
+// provider, as expected by providerserver.
+func NewFrameworkProvider(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &frameworkProvider{version: version}
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "fastly"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_key": schema.StringAttribute{
+				Optional:    true,
+				Description: "Fastly API Key from https://app.fastly.com/#account",
+			},
+			"base_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "Fastly API URL",
+			},
+			"force_http2": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Set this to `true` to disable HTTP/1.x fallback mechanism that the underlying Go library will attempt upon connection to `api.fastly.com:443` by default. This may slightly improve the provider's performance and reduce unnecessary TLS handshakes. Default: `false`",
+			},
+			"login_case_insensitive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether logins are matched against existing users and invitations ignoring case. Default: `true`",
+			},
+			"login_ignore_plus_suffix": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether `user+tag@example.com` is matched as `user@example.com`. Default: `false`",
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Apply the same defaults as the SDKv2 schema.
+	apiKey := os.Getenv("FASTLY_API_KEY")
+	if !data.APIKey.IsNull() {
+		apiKey = data.APIKey.ValueString()
+	}
+	baseURL := gofastly.DefaultEndpoint
+	if v := os.Getenv("FASTLY_API_URL"); v != "" {
+		baseURL = v
+	}
+	if !data.BaseURL.IsNull() {
+		baseURL = data.BaseURL.ValueString()
+	}
+	normalization := DefaultLoginNormalization
+	if !data.LoginCaseInsensitive.IsNull() {
+		normalization.CaseInsensitive = data.LoginCaseInsensitive.ValueBool()
+	}
+	if !data.LoginIgnorePlusSuffix.IsNull() {
+		normalization.IgnorePlusSuffix = data.LoginIgnorePlusSuffix.ValueBool()
+	}
+
+	config := Config{
+		APIKey:             apiKey,
+		BaseURL:            baseURL,
+		ForceHTTP2:         data.ForceHTTP2.ValueBool(),
+		NoAuth:             false, // User management always requires auth
+		UserAgent:          frameworkUserAgent(req.TerraformVersion, p.version),
+		Context:            ctx,
+		LoginNormalization: normalization,
+	}
+
+	client, diags := config.Client()
+	appendSDKDiagnostics(&resp.Diagnostics, diags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
+	resp.ListResourceData = client
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newAPITokenEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newNormalizeLoginFunction,
+		newEmailDomainFunction,
+		newRoleRankFunction,
+		newRoleAtLeastFunction,
+	}
+}
+
+func (p *frameworkProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		newResendInvitationAction,
+		newResetUserPasswordAction,
+		newLockUserAction,
+	}
+}
+
+func (p *frameworkProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		newUserListResource,
+	}
+}
+
+// frameworkUserAgent builds the same style of User-Agent as
+// schema.Provider.UserAgent does for the SDKv2 provider.
+func frameworkUserAgent(terraformVersion, providerVersion string) string {
+	return fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s/%s",
+		terraformVersion, TerraformProviderProductUserAgent, providerVersion)
+}
+
+// appendSDKDiagnostics copies SDKv2 diagnostics, as returned by
+// Config.Client, into framework diagnostics.
+func appendSDKDiagnostics(to *fwdiag.Diagnostics, diags diag.Diagnostics) {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			to.AddError(d.Summary, d.Detail)
+		} else {
+			to.AddWarning(d.Summary, d.Detail)
+		}
+	}
+}
+
+// frameworkAPIClient returns the *APIClient passed to framework resources,
+// actions and the like by Configure. It returns nil before the provider is
+// configured.
+func frameworkAPIClient(providerData any, diags *fwdiag.Diagnostics) *APIClient {
+	if providerData == nil {
+		return nil
+	}
+
+	client, ok := providerData.(*APIClient)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("expected *APIClient, got %T", providerData))
+		return nil
+	}
+	return client
+}
+
+// checkConfiguredClient reports an error and returns false if client is nil,
+// which happens when a framework resource, action or list resource is used
+// before the provider has been configured.
+func checkConfiguredClient(client *APIClient, diags *fwdiag.Diagnostics) bool {
+	if client != nil {
+		return true
+	}
+	diags.AddError(
+		"Unconfigured provider",
+		"The provider has not been configured yet, so no API client is available. This is usually caused by provider arguments that depend on values not known until apply; please report it to the provider developers if it persists.",
+	)
+	return false
+}
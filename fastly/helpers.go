@@ -0,0 +1,98 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+// getOptionalBool returns the configured value of an optional boolean
+// attribute, or nil if it was not set in the configuration. d.GetOk cannot be
+// used for this as it treats an explicit false the same as unset.
+func getOptionalBool(d *schema.ResourceData, key string) *bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+
+	v := raw.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	b := v.True()
+	return &b
+}
+
+// emailDomain returns the lower-cased domain part of an email address, or an
+// empty string if the address has no domain.
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[i+1:]))
+}
+
+// currentCustomerID returns the customer ID of the user that owns the API key.
+func currentCustomerID(ctx context.Context, conn *gofastly.Client) (string, error) {
+	currentUser, err := conn.GetCurrentUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error getting current user: %w", err)
+	}
+	return gofastly.ToValue(currentUser.CustomerID), nil
+}
+
+// listUsersAndInvitations returns the customer ID of the API key, the users of
+// that account and, if includeInvitations is set, the pending invitations for
+// logins that are not users yet. Both are sorted by login.
+func listUsersAndInvitations(ctx context.Context, client *APIClient, includeInvitations bool) (string, []*gofastly.User, []*Invitation, error) {
+	customerID, err := currentCustomerID(ctx, client.conn)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	users, err := client.conn.ListCustomerUsers(ctx, &gofastly.ListCustomerUsersInput{
+		CustomerID: customerID,
+	})
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("error listing users: %w", err)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return gofastly.ToValue(users[i].Login) < gofastly.ToValue(users[j].Login)
+	})
+
+	if !includeInvitations {
+		return customerID, users, nil, nil
+	}
+
+	resp, err := listInvitations(ctx, client)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("error listing invitations: %w", err)
+	}
+
+	known := make(map[string]bool, len(users))
+	for _, u := range users {
+		known[client.loginNormalization.Normalize(gofastly.ToValue(u.Login))] = true
+	}
+
+	var invitations []*Invitation
+	for _, v := range resp.Data {
+		inv := flattenInvitation(v)
+		// An accepted invitation can linger until it expires.
+		if inv.Status() != "pending" || known[client.loginNormalization.Normalize(inv.Email)] {
+			continue
+		}
+		invitations = append(invitations, inv)
+	}
+	sort.Slice(invitations, func(i, j int) bool {
+		return invitations[i].Email < invitations[j].Email
+	})
+
+	return customerID, users, invitations, nil
+}
@@ -0,0 +1,198 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+var (
+	_ list.ListResource                 = &userListResource{}
+	_ list.ListResourceWithConfigure    = &userListResource{}
+	_ list.ListResourceWithRawV5Schemas = &userListResource{}
+)
+
+// userListResource lists users and pending invitations as fastly_user
+// resources, for `terraform query`. fastly_user itself is an SDKv2 resource,
+// so results are built with the same helpers its importer uses.
+type userListResource struct {
+	client *APIClient
+}
+
+type userListModel struct {
+	Role               types.String `tfsdk:"role"`
+	IncludeInvitations types.Bool   `tfsdk:"include_invitations"`
+}
+
+func newUserListResource() list.ListResource {
+	return &userListResource{}
+}
+
+func (r *userListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *userListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the users and pending invitations of the account that owns the API key",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include users and invitations with this role",
+				Validators:  []validator.String{userRoleValidator{}},
+			},
+			"include_invitations": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to include pending invitations. Default: `true`",
+			},
+		},
+	}
+}
+
+func (r *userListResource) RawV5Schemas(ctx context.Context, _ list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	res := resourceUser(nil)
+	resp.ProtoV5Schema = res.ProtoSchema(ctx)()
+	resp.ProtoV5IdentitySchema = res.ProtoIdentitySchema(ctx)()
+}
+
+func (r *userListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = frameworkAPIClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *userListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags fwdiag.Diagnostics
+	if !checkConfiguredClient(r.client, &diags) {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var data userListModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	role := data.Role.ValueString()
+	includeInvitations := data.IncludeInvitations.IsNull() || data.IncludeInvitations.ValueBool()
+
+	customerID, users, invitations, err := listUsersAndInvitations(ctx, r.client, includeInvitations)
+	if err != nil {
+		stream.Results = listResultsStreamError("Error listing users", err)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, u := range users {
+			login := gofastly.ToValue(u.Login)
+			if role != "" && gofastly.ToValue(u.Role) != role {
+				continue
+			}
+
+			result := newUserListResult(ctx, req, customerID, login, gofastly.ToValue(u.Name), func(d *sdkschema.ResourceData) error {
+				if err := importUserState(d, gofastly.ToValue(u.UserID), login); err != nil {
+					return err
+				}
+				return setUserAttributes(d, u)
+			})
+			if !push(result) {
+				return
+			}
+		}
+
+		for _, inv := range invitations {
+			if role != "" && inv.Role != role {
+				continue
+			}
+
+			result := newUserListResult(ctx, req, customerID, inv.Email, inv.Email+" (invitation pending)", func(d *sdkschema.ResourceData) error {
+				if err := importInvitationState(d, inv); err != nil {
+					return err
+				}
+				return setInvitationAttributes(d, inv)
+			})
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// newUserListResult builds the result for one user or invitation. set fills
+// in the fastly_user state, and is only called when the resource is
+// requested.
+func newUserListResult(ctx context.Context, req list.ListRequest, customerID, login, displayName string, set func(*sdkschema.ResourceData) error) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+
+	result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("customer_id"), customerID)...)
+	result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("login"), login)...)
+
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	res := resourceUser(nil)
+	d := res.Data(nil)
+	if err := set(d); err != nil {
+		result.Diagnostics.AddError("Error reading fastly_user", fmt.Sprintf("%s: %s", login, err))
+		return result
+	}
+	if err := d.Set("customer_id", customerID); err != nil {
+		result.Diagnostics.AddError("Error reading fastly_user", fmt.Sprintf("%s: %s", login, err))
+		return result
+	}
+	if _, err := resourceUserImportDefaults(d); err != nil {
+		result.Diagnostics.AddError("Error reading fastly_user", fmt.Sprintf("%s: %s", login, err))
+		return result
+	}
+
+	raw, err := sdkStateToTerraformValue(res, d, req.ResourceSchema.Type().TerraformType(ctx))
+	if err != nil {
+		result.Diagnostics.AddError("Error converting fastly_user", fmt.Sprintf("%s: %s", login, err))
+		return result
+	}
+	result.Resource = &tfsdk.Resource{
+		Raw:    raw,
+		Schema: req.ResourceSchema,
+	}
+
+	return result
+}
+
+// sdkStateToTerraformValue converts the state of an SDKv2 resource into a
+// value of the framework's raw type.
+func sdkStateToTerraformValue(res *sdkschema.Resource, d *sdkschema.ResourceData, typ tftypes.Type) (tftypes.Value, error) {
+	ty := res.CoreConfigSchema().ImpliedType()
+
+	val, err := d.State().AttrsAsObjectValue(ty)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	b, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return tftypes.ValueFromJSONWithOpts(b, typ, tftypes.ValueFromJSONOpts{})
+}
+
+// listResultsStreamError returns a stream of a single error diagnostic.
+func listResultsStreamError(summary string, err error) iter.Seq[list.ListResult] {
+	var diags fwdiag.Diagnostics
+	diags.AddError(summary, err.Error())
+	return list.ListResultsStreamDiagnostics(diags)
+}
@@ -0,0 +1,103 @@
+package fastly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+func TestSDKStateToTerraformValue(t *testing.T) {
+	ctx := context.Background()
+	res := resourceUser(nil)
+
+	d := res.Data(nil)
+	u := &gofastly.User{
+		UserID: gofastly.ToPointer("user123"),
+		Login:  gofastly.ToPointer("jane@example.com"),
+		Name:   gofastly.ToPointer("Jane"),
+		Role:   gofastly.ToPointer("engineer"),
+		Locked: gofastly.ToPointer(true),
+	}
+	if err := importUserState(d, "user123", "jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := setUserAttributes(d, u); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resourceUserImportDefaults(d); err != nil {
+		t.Fatal(err)
+	}
+
+	val, err := sdkStateToTerraformValue(res, d, res.ProtoSchema(ctx)().ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := val.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"id":         "jane@example.com",
+		"login":      "jane@example.com",
+		"user_id":    "user123",
+		"role":       "engineer",
+		"status":     userStatusLocked,
+		"on_destroy": onDestroyDelete,
+	}
+	for k, v := range want {
+		var got string
+		if err := attrs[k].As(&got); err != nil {
+			t.Fatalf("%s: %s", k, err)
+		}
+		if got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+}
+
+func TestUserListResourceUnconfigured(t *testing.T) {
+	r := &userListResource{}
+
+	var stream list.ListResultsStream
+	r.List(context.Background(), list.ListRequest{}, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	if len(results) != 1 || !results[0].Diagnostics.HasError() || results[0].Diagnostics[0].Summary() != "Unconfigured provider" {
+		t.Errorf("expected a single unconfigured provider error, got %v", results)
+	}
+}
+
+func TestUserListResourceRoleValidator(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"valid", types.StringValue("engineer"), false},
+		{"typo", types.StringValue("enginer"), true},
+		{"wrong case", types.StringValue("Engineer"), true},
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+	}
+
+	for _, tc := range cases {
+		req := validator.StringRequest{Path: path.Root("role"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		userRoleValidator{}.ValidateString(context.Background(), req, &resp)
+		if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+			t.Errorf("%s: error = %t, want %t (%v)", tc.name, got, tc.wantErr, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package fastly
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// LoginNormalization controls how logins (email addresses) are compared when
+// matching configuration against users and invitations in Fastly.
+type LoginNormalization struct {
+	// CaseInsensitive compares logins ignoring case.
+	CaseInsensitive bool
+	// IgnorePlusSuffix treats user+tag@example.com as user@example.com.
+	IgnorePlusSuffix bool
+}
+
+// DefaultLoginNormalization is used when the provider configuration does not
+// override the normalization rules.
+var DefaultLoginNormalization = LoginNormalization{
+	CaseInsensitive: true,
+}
+
+// Normalize returns the canonical form of a login. Surrounding whitespace is
+// always removed.
+func (n LoginNormalization) Normalize(login string) string {
+	login = strings.TrimSpace(login)
+	if n.CaseInsensitive {
+		login = strings.ToLower(login)
+	}
+	if n.IgnorePlusSuffix {
+		if at := strings.LastIndex(login, "@"); at > 0 {
+			local, domain := login[:at], login[at:]
+			if plus := strings.Index(local, "+"); plus > 0 {
+				local = local[:plus]
+			}
+			login = local + domain
+		}
+	}
+	return login
+}
+
+// Equal reports whether two logins are the same after normalization.
+func (n LoginNormalization) Equal(a, b string) bool {
+	return n.Normalize(a) == n.Normalize(b)
+}
+
+// suppressEquivalentLogin returns a DiffSuppressFunc that suppresses diffs
+// between logins that only differ in ways the normalization rules of the
+// client returned by configured ignore. A DiffSuppressFunc has no access to
+// the provider meta, so the client is looked up through configured, which may
+// be nil or return nil before the provider is configured; the default rules
+// apply then.
+func suppressEquivalentLogin(configured func() *APIClient) schema.SchemaDiffSuppressFunc {
+	return func(_, old, new string, _ *schema.ResourceData) bool {
+		rules := DefaultLoginNormalization
+		if configured != nil {
+			if client := configured(); client != nil {
+				rules = client.loginNormalization
+			}
+		}
+		return old != "" && rules.Equal(old, new)
+	}
+}
@@ -0,0 +1,51 @@
+package fastly
+
+import "testing"
+
+func TestLoginNormalization(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules LoginNormalization
+		a, b  string
+		want  bool
+	}{
+		{"exact", LoginNormalization{}, "jane@example.com", "jane@example.com", true},
+		{"whitespace", LoginNormalization{}, " jane@example.com ", "jane@example.com", true},
+		{"case sensitive", LoginNormalization{}, "Jane.Doe@Example.com", "jane.doe@example.com", false},
+		{"case insensitive", DefaultLoginNormalization, "Jane.Doe@Example.com", "jane.doe@example.com", true},
+		{"plus suffix kept", DefaultLoginNormalization, "jane+fastly@example.com", "jane@example.com", false},
+		{"plus suffix ignored", LoginNormalization{CaseInsensitive: true, IgnorePlusSuffix: true}, "Jane+Fastly@example.com", "jane@example.com", true},
+		{"plus in domain", LoginNormalization{IgnorePlusSuffix: true}, "jane@ex+ample.com", "jane@ex+ample.com", true},
+		{"different users", DefaultLoginNormalization, "jane@example.com", "john@example.com", false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.rules.Equal(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s: Equal(%q, %q) = %t, want %t", tc.name, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestSuppressEquivalentLogin(t *testing.T) {
+	caseSensitive := &APIClient{loginNormalization: LoginNormalization{}}
+	ignorePlus := &APIClient{loginNormalization: LoginNormalization{CaseInsensitive: true, IgnorePlusSuffix: true}}
+
+	cases := []struct {
+		name       string
+		configured func() *APIClient
+		old, new   string
+		want       bool
+	}{
+		{"unconfigured uses defaults", nil, "Jane@example.com", "jane@example.com", true},
+		{"not yet configured", func() *APIClient { return nil }, "Jane@example.com", "jane@example.com", true},
+		{"case sensitive client", func() *APIClient { return caseSensitive }, "Jane@example.com", "jane@example.com", false},
+		{"plus suffix client", func() *APIClient { return ignorePlus }, "jane+fastly@example.com", "Jane@example.com", true},
+		{"new resource", func() *APIClient { return ignorePlus }, "", "jane@example.com", false},
+	}
+
+	for _, tc := range cases {
+		if got := suppressEquivalentLogin(tc.configured)("login", tc.old, tc.new, nil); got != tc.want {
+			t.Errorf("%s: suppress(%q, %q) = %t, want %t", tc.name, tc.old, tc.new, got, tc.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package fastly
 import (
 	"context"
 	"os"
+	"sync/atomic"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +24,10 @@ var DisplaySensitiveFields = false
 func Provider() *schema.Provider {
 	DisplaySensitiveFields = os.Getenv("FASTLY_TF_DISPLAY_SENSITIVE_FIELDS") == "true"
 
+	// client is the configured client of this provider instance, for the
+	// parts of resources that have no access to the provider meta.
+	var client atomic.Pointer[APIClient]
+
 	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
@@ -43,13 +48,27 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: "Set this to `true` to disable HTTP/1.x fallback mechanism that the underlying Go library will attempt upon connection to `api.fastly.com:443` by default. This may slightly improve the provider's performance and reduce unnecessary TLS handshakes. Default: `false`",
 			},
+			"login_case_insensitive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     DefaultLoginNormalization.CaseInsensitive,
+				Description: "Whether logins are matched against existing users and invitations ignoring case. Default: `true`",
+			},
+			"login_ignore_plus_suffix": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     DefaultLoginNormalization.IgnorePlusSuffix,
+				Description: "Whether `user+tag@example.com` is matched as `user@example.com`. Default: `false`",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"fastly_users":       dataSourceFastlyUsers(),
 			"fastly_invitations": dataSourceFastlyInvitations(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"fastly_user": resourceUser(),
+			"fastly_user":              resourceUser(client.Load),
+			"fastly_customer_settings": resourceCustomerSettings(),
+			"fastly_customer_contact":  resourceCustomerContact(),
 		},
 	}
 
@@ -61,8 +80,17 @@ func Provider() *schema.Provider {
 			NoAuth:     false, // User management always requires auth
 			UserAgent:  provider.UserAgent(TerraformProviderProductUserAgent, version.ProviderVersion),
 			Context:    ctx,
+			LoginNormalization: LoginNormalization{
+				CaseInsensitive:  d.Get("login_case_insensitive").(bool),
+				IgnorePlusSuffix: d.Get("login_ignore_plus_suffix").(bool),
+			},
+		}
+
+		c, diags := config.Client()
+		if !diags.HasError() {
+			client.Store(c)
 		}
-		return config.Client()
+		return c, diags
 	}
 
 	return provider
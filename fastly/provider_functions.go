@@ -0,0 +1,178 @@
+package fastly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// NOTE: Provider functions cannot read the provider configuration, so
+// normalize_login always applies DefaultLoginNormalization.
+
+var (
+	_ function.Function = &normalizeLoginFunction{}
+	_ function.Function = &emailDomainFunction{}
+	_ function.Function = &roleRankFunction{}
+	_ function.Function = &roleAtLeastFunction{}
+)
+
+// normalizeLoginFunction implements normalize_login(email).
+type normalizeLoginFunction struct{}
+
+func newNormalizeLoginFunction() function.Function {
+	return &normalizeLoginFunction{}
+}
+
+func (f *normalizeLoginFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_login"
+}
+
+func (f *normalizeLoginFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a login",
+		Description: "Returns the login in the canonical form the provider uses to match users and invitations: surrounding whitespace removed and lower-cased",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "email",
+				Description: "The login (email address) to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeLoginFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var email string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &email))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, DefaultLoginNormalization.Normalize(email)))
+}
+
+// emailDomainFunction implements email_domain(email).
+type emailDomainFunction struct{}
+
+func newEmailDomainFunction() function.Function {
+	return &emailDomainFunction{}
+}
+
+func (f *emailDomainFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "email_domain"
+}
+
+func (f *emailDomainFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Domain of an email address",
+		Description: "Returns the lower-cased domain of an email address, as matched by the email_domain filter of the fastly_users data source, or an empty string if the address has no domain",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "email",
+				Description: "The email address",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *emailDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var email string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &email))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, emailDomain(email)))
+}
+
+// roleRankFunction implements role_rank(role).
+type roleRankFunction struct{}
+
+func newRoleRankFunction() function.Function {
+	return &roleRankFunction{}
+}
+
+func (f *roleRankFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "role_rank"
+}
+
+func (f *roleRankFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Rank of a user role",
+		Description: "Returns the rank of a user role, from 1 for `user` through `billing` and `engineer` to 4 for `superuser`",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "role",
+				Description: "The user role",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *roleRankFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var role string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &role))
+	if resp.Error != nil {
+		return
+	}
+
+	rank, err := roleRank(role)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, int64(rank)))
+}
+
+// roleAtLeastFunction implements role_at_least(role, min).
+type roleAtLeastFunction struct{}
+
+func newRoleAtLeastFunction() function.Function {
+	return &roleAtLeastFunction{}
+}
+
+func (f *roleAtLeastFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "role_at_least"
+}
+
+func (f *roleAtLeastFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compare user roles",
+		Description: "Returns whether a user role is at least as privileged as another, using the same ordering as role_rank",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "role",
+				Description: "The user role to check",
+			},
+			function.StringParameter{
+				Name:        "min",
+				Description: "The least privileged role that is accepted",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *roleAtLeastFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var role, min string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &role, &min))
+	if resp.Error != nil {
+		return
+	}
+
+	rank, err := roleRank(role)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	minRank, err := roleRank(min)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, rank >= minRank))
+}
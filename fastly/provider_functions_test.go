@@ -0,0 +1,103 @@
+package fastly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRoleRank(t *testing.T) {
+	previous := 0
+	for _, role := range userRoles {
+		rank, err := roleRank(role)
+		if err != nil {
+			t.Fatalf("roleRank(%q): unexpected error: %s", role, err)
+		}
+		if rank <= previous {
+			t.Errorf("roleRank(%q) = %d, want more than %d", role, rank, previous)
+		}
+		previous = rank
+	}
+
+	if _, err := roleRank("admin"); err == nil {
+		t.Error("roleRank(\"admin\"): expected an error")
+	}
+}
+
+func TestProviderFunctions(t *testing.T) {
+	cases := []struct {
+		name    string
+		fn      function.Function
+		args    []attr.Value
+		unknown attr.Value
+		want    attr.Value
+		wantErr bool
+	}{
+		{
+			name:    "normalize_login",
+			fn:      newNormalizeLoginFunction(),
+			args:    []attr.Value{types.StringValue(" Jane.Doe@Example.com ")},
+			unknown: types.StringUnknown(),
+			want:    types.StringValue("jane.doe@example.com"),
+		},
+		{
+			name:    "email_domain",
+			fn:      newEmailDomainFunction(),
+			args:    []attr.Value{types.StringValue("jane@Example.com")},
+			unknown: types.StringUnknown(),
+			want:    types.StringValue("example.com"),
+		},
+		{
+			name:    "role_rank",
+			fn:      newRoleRankFunction(),
+			args:    []attr.Value{types.StringValue("engineer")},
+			unknown: types.Int64Unknown(),
+			want:    types.Int64Value(3),
+		},
+		{
+			name:    "role_rank unknown role",
+			fn:      newRoleRankFunction(),
+			args:    []attr.Value{types.StringValue("admin")},
+			unknown: types.Int64Unknown(),
+			want:    types.Int64Unknown(),
+			wantErr: true,
+		},
+		{
+			name:    "role_at_least",
+			fn:      newRoleAtLeastFunction(),
+			args:    []attr.Value{types.StringValue("superuser"), types.StringValue("engineer")},
+			unknown: types.BoolUnknown(),
+			want:    types.BoolValue(true),
+		},
+		{
+			name:    "role_at_least lower role",
+			fn:      newRoleAtLeastFunction(),
+			args:    []attr.Value{types.StringValue("billing"), types.StringValue("engineer")},
+			unknown: types.BoolUnknown(),
+			want:    types.BoolValue(false),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			req := function.RunRequest{Arguments: function.NewArgumentsData(c.args)}
+			resp := &function.RunResponse{Result: function.NewResultData(c.unknown)}
+
+			c.fn.Run(ctx, req, resp)
+
+			if (resp.Error != nil) != c.wantErr {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+			if c.wantErr {
+				return
+			}
+			if got := resp.Result.Value(); !got.Equal(c.want) {
+				t.Errorf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+}
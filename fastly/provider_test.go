@@ -1,15 +1,35 @@
 package fastly
 
 import (
+	"context"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var testAccProviders map[string]func() (*schema.Provider, error)
 var testAccProvider *schema.Provider
 
+// testAccProtoV5ProviderFactories serves both the SDKv2 and the framework
+// provider, for tests that use framework-only features. It reuses
+// testAccProvider so check functions can use its client.
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"fastly": func() (tfprotov5.ProviderServer, error) {
+		muxServer, err := tf5muxserver.NewMuxServer(context.Background(),
+			testAccProvider.GRPCProvider,
+			providerserver.NewProtocol5(NewFrameworkProvider("test")()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return muxServer.ProviderServer(), nil
+	},
+}
+
 func init() {
 	testAccProvider = Provider()
 	testAccProviders = map[string]func() (*schema.Provider, error){
@@ -25,3 +45,30 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+func TestMuxServer(t *testing.T) {
+	ctx := context.Background()
+
+	providerServer, err := NewMuxServer(ctx, "test")
+	if err != nil {
+		t.Fatalf("error creating mux server: %s", err)
+	}
+
+	// The mux server reports an error if the SDKv2 and framework provider
+	// schemas differ.
+	resp, err := providerServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("error getting provider schema: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Errorf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas["fastly_user"]; !ok {
+		t.Error("expected fastly_user to be served by the mux server")
+	}
+	if _, ok := resp.ListResourceSchemas["fastly_user"]; !ok {
+		t.Error("expected the fastly_user list resource to be served by the mux server")
+	}
+}
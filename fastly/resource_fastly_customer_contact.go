@@ -0,0 +1,304 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+// customerContact is a contact of a customer account as returned by
+// /customer/{customer_id}/contacts.
+type customerContact struct {
+	ContactID   *string `mapstructure:"id"`
+	ContactType *string `mapstructure:"contact_type"`
+	CustomerID  *string `mapstructure:"customer_id"`
+	UserID      *string `mapstructure:"user_id"`
+	Firstname   *string `mapstructure:"firstname"`
+	Lastname    *string `mapstructure:"lastname"`
+	Email       *string `mapstructure:"email"`
+	Phone       *string `mapstructure:"phone"`
+}
+
+// createCustomerContactInput is the form body of POST /customer/{customer_id}/contacts.
+type createCustomerContactInput struct {
+	ContactType *string `url:"contact_type,omitempty"`
+	UserID      *string `url:"user_id,omitempty"`
+	Firstname   *string `url:"firstname,omitempty"`
+	Lastname    *string `url:"lastname,omitempty"`
+	Email       *string `url:"email,omitempty"`
+	Phone       *string `url:"phone,omitempty"`
+}
+
+func resourceCustomerContact() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCustomerContactCreate,
+		ReadContext:   resourceCustomerContactRead,
+		DeleteContext: resourceCustomerContactDelete,
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: resourceCustomerContactIdentitySchema,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCustomerContactImport,
+		},
+
+		// The API has no update operation for contacts, so every argument
+		// forces a new contact to be created.
+		Schema: map[string]*schema.Schema{
+			"customer_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer account. Defaults to the account that owns the API key",
+			},
+
+			"contact_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The type of contact. Can be `primary`, `billing`, `technical`, `security`, `emergency` or `general compliance`",
+				ValidateDiagFunc: validateContactType(),
+			},
+
+			"user_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The ID of an existing user to use as the contact. Conflicts with `firstname`, `lastname`, `email` and `phone`",
+				ConflictsWith: []string{"firstname", "lastname", "email", "phone"},
+				AtLeastOneOf:  []string{"user_id", "email"},
+			},
+
+			"firstname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The first name of the contact",
+			},
+
+			"lastname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The last name of the contact",
+			},
+
+			"email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The email address of the contact",
+				AtLeastOneOf: []string{"user_id", "email"},
+			},
+
+			"phone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The phone number of the contact",
+			},
+		},
+	}
+}
+
+func resourceCustomerContactCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).conn
+
+	customerID := d.Get("customer_id").(string)
+	if customerID == "" {
+		var err error
+		customerID, err = currentCustomerID(ctx, conn)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	input := &createCustomerContactInput{
+		ContactType: gofastly.ToPointer(d.Get("contact_type").(string)),
+		UserID:      gofastly.NullString(d.Get("user_id").(string)),
+		Firstname:   gofastly.NullString(d.Get("firstname").(string)),
+		Lastname:    gofastly.NullString(d.Get("lastname").(string)),
+		Email:       gofastly.NullString(d.Get("email").(string)),
+		Phone:       gofastly.NullString(d.Get("phone").(string)),
+	}
+
+	resp, err := conn.PostForm(ctx, gofastly.ToSafeURL("customer", customerID, "contacts"), input, gofastly.CreateRequestOptions())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating customer contact: %w", err))
+	}
+	defer resp.Body.Close()
+
+	var contact *customerContact
+	if err := gofastly.DecodeBodyMap(resp.Body, &contact); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(gofastly.ToValue(contact.ContactID))
+	if err := d.Set("customer_id", customerID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Created %s contact for customer %s: %s", d.Get("contact_type").(string), customerID, d.Id())
+
+	return resourceCustomerContactRead(ctx, d, meta)
+}
+
+func resourceCustomerContactRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[DEBUG] Refreshing Customer Contact for (%s)", d.Id())
+	conn := meta.(*APIClient).conn
+
+	contacts, err := listCustomerContacts(ctx, conn, d.Get("customer_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var contact *customerContact
+	for _, c := range contacts {
+		if gofastly.ToValue(c.ContactID) == d.Id() {
+			contact = c
+			break
+		}
+	}
+
+	if contact == nil {
+		log.Printf("[WARN] Customer Contact (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("contact_type", gofastly.ToValue(contact.ContactType)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setCustomerContactIdentity(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// When the contact is linked to a user, the API fills in the user's
+	// details, which must not be written back as they conflict with user_id.
+	if userID := gofastly.ToValue(contact.UserID); userID != "" {
+		if err := d.Set("user_id", userID); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	if err := d.Set("firstname", gofastly.ToValue(contact.Firstname)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("lastname", gofastly.ToValue(contact.Lastname)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("email", gofastly.ToValue(contact.Email)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("phone", gofastly.ToValue(contact.Phone)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerContactDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).conn
+
+	path := gofastly.ToSafeURL("customer", d.Get("customer_id").(string), "contacts", d.Id())
+
+	resp, err := conn.Delete(ctx, path, gofastly.CreateRequestOptions())
+	if err != nil {
+		// Ignore not found errors
+		if httpErr, ok := err.(*gofastly.HTTPError); ok && httpErr.IsNotFound() {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceCustomerContactIdentitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"customer_id": {
+			Type:              schema.TypeString,
+			OptionalForImport: true,
+			Description:       "The ID of the customer account. Defaults to the account that owns the API key",
+		},
+		"contact_id": {
+			Type:              schema.TypeString,
+			RequiredForImport: true,
+			Description:       "The ID of the contact",
+		},
+	}
+}
+
+func setCustomerContactIdentity(d *schema.ResourceData) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	if err := identity.Set("customer_id", d.Get("customer_id").(string)); err != nil {
+		return err
+	}
+	return identity.Set("contact_id", d.Id())
+}
+
+// resourceCustomerContactImport accepts IDs of the form
+// <customer_id>/<contact_id>, or an identity.
+func resourceCustomerContactImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	// Import by identity, which has no ID
+	if d.Id() == "" {
+		identity, err := d.Identity()
+		if err != nil {
+			return nil, fmt.Errorf("error getting identity: %w", err)
+		}
+		contactID, _ := identity.Get("contact_id").(string)
+		if contactID == "" {
+			return nil, fmt.Errorf("expected identity to contain contact_id")
+		}
+		customerID, _ := identity.Get("customer_id").(string)
+		if customerID == "" {
+			customerID, err = currentCustomerID(ctx, meta.(*APIClient).conn)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		d.SetId(contactID)
+		if err := d.Set("customer_id", customerID); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
+	customerID, contactID, ok := strings.Cut(d.Id(), "/")
+	if !ok || customerID == "" || contactID == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected <customer_id>/<contact_id>", d.Id())
+	}
+
+	d.SetId(contactID)
+	if err := d.Set("customer_id", customerID); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func listCustomerContacts(ctx context.Context, conn *gofastly.Client, customerID string) ([]*customerContact, error) {
+	resp, err := conn.Get(ctx, gofastly.ToSafeURL("customer", customerID, "contacts"), gofastly.CreateRequestOptions())
+	if err != nil {
+		return nil, fmt.Errorf("error listing customer contacts: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var contacts []*customerContact
+	if err := gofastly.DecodeBodyMap(resp.Body, &contacts); err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
@@ -0,0 +1,108 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+const fastlyCustomerContact = "fastly_customer_contact.foo"
+
+func TestAccFastlyCustomerContact_basic(t *testing.T) {
+	email := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckCustomerContactDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerContactConfig("technical", email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						fastlyCustomerContact, "contact_type", "technical"),
+					resource.TestCheckResourceAttr(
+						fastlyCustomerContact, "email", email),
+					resource.TestCheckResourceAttrSet(
+						fastlyCustomerContact, "customer_id"),
+				),
+			},
+			{
+				ResourceName:      fastlyCustomerContact,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[fastlyCustomerContact]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", fastlyCustomerContact)
+					}
+					return rs.Primary.Attributes["customer_id"] + "/" + rs.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckCustomerContactDestroy verifies that contacts are deleted.
+func testAccCheckCustomerContactDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "fastly_customer_contact" {
+			continue
+		}
+
+		conn := testAccProvider.Meta().(*APIClient).conn
+		contacts, err := listCustomerContacts(context.TODO(), conn, rs.Primary.Attributes["customer_id"])
+		if err != nil {
+			return fmt.Errorf("error listing contacts when checking destroy: %s", err)
+		}
+
+		for _, c := range contacts {
+			if gofastly.ToValue(c.ContactID) == rs.Primary.ID {
+				return fmt.Errorf("contact (%s) still exists after destroy", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCustomerContactConfig(contactType, email string) string {
+	return fmt.Sprintf(`
+resource "fastly_customer_contact" "foo" {
+	contact_type = "%s"
+	firstname    = "Terraform"
+	lastname     = "Test"
+	email        = "%s"
+}`, contactType, email)
+}
+
+func TestSetCustomerContactIdentity(t *testing.T) {
+	d := schema.TestResourceDataWithIdentityRaw(t, resourceCustomerContact().SchemaMap(), resourceCustomerContactIdentitySchema(), map[string]string{})
+	d.SetId("contact123")
+	if err := d.Set("customer_id", "cust123"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setCustomerContactIdentity(d); err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := identity.Get("customer_id"); got != "cust123" {
+		t.Errorf("customer_id = %v, want cust123", got)
+	}
+	if got := identity.Get("contact_id"); got != "contact123" {
+		t.Errorf("contact_id = %v, want contact123", got)
+	}
+}
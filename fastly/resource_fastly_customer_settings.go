@@ -0,0 +1,215 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+// customerSettings holds the security settings of a customer account as
+// returned by GET /customer/{customer_id}.
+type customerSettings struct {
+	CustomerID *string `mapstructure:"id"`
+	Force2FA   *bool   `mapstructure:"force_2fa"`
+	ForceSSO   *bool   `mapstructure:"force_sso"`
+}
+
+// updateCustomerSettingsInput is the form body of PUT /customer/{customer_id}.
+type updateCustomerSettingsInput struct {
+	Force2FA *gofastly.Compatibool `url:"force_2fa,omitempty"`
+	ForceSSO *gofastly.Compatibool `url:"force_sso,omitempty"`
+}
+
+func resourceCustomerSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCustomerSettingsCreate,
+		ReadContext:   resourceCustomerSettingsRead,
+		UpdateContext: resourceCustomerSettingsUpdate,
+		DeleteContext: resourceCustomerSettingsDelete,
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: resourceCustomerSettingsIdentitySchema,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCustomerSettingsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"customer_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the customer account to manage. Defaults to the account that owns the API key",
+			},
+
+			"force_2fa": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether all users of the account are required to use two-factor authentication. Left unchanged when not set",
+			},
+
+			"force_sso": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether all users of the account are required to sign in with single sign-on. Left unchanged when not set",
+			},
+		},
+	}
+}
+
+func resourceCustomerSettingsCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).conn
+
+	customerID := d.Get("customer_id").(string)
+	if customerID == "" {
+		var err error
+		customerID, err = currentCustomerID(ctx, conn)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The settings always exist for an account, so creating the resource
+	// just takes over management of them.
+	if err := updateCustomerSettings(ctx, conn, customerID, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(customerID)
+
+	return resourceCustomerSettingsRead(ctx, d, meta)
+}
+
+func resourceCustomerSettingsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[DEBUG] Refreshing Customer Settings for (%s)", d.Id())
+	conn := meta.(*APIClient).conn
+
+	settings, err := getCustomerSettings(ctx, conn, d.Id())
+	if err != nil {
+		if httpErr, ok := err.(*gofastly.HTTPError); ok && httpErr.IsNotFound() {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("customer_id", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("force_2fa", gofastly.ToValue(settings.Force2FA)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("force_sso", gofastly.ToValue(settings.ForceSSO)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := identity.Set("customer_id", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).conn
+
+	if d.HasChanges("force_2fa", "force_sso") {
+		if err := updateCustomerSettings(ctx, conn, d.Id(), d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceCustomerSettingsRead(ctx, d, meta)
+}
+
+func resourceCustomerSettingsDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	// The settings cannot be deleted, and silently turning off 2FA or SSO
+	// enforcement would be surprising, so we only stop managing them.
+	log.Printf("[DEBUG] Removing Customer Settings for (%s) from state; the account settings are left unchanged", d.Id())
+	return nil
+}
+
+func resourceCustomerSettingsIdentitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"customer_id": {
+			Type:              schema.TypeString,
+			RequiredForImport: true,
+			Description:       "The ID of the customer account",
+		},
+	}
+}
+
+// resourceCustomerSettingsImport accepts the customer ID as import ID, or an
+// identity.
+func resourceCustomerSettingsImport(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	if d.Id() == "" {
+		identity, err := d.Identity()
+		if err != nil {
+			return nil, fmt.Errorf("error getting identity: %w", err)
+		}
+		customerID, _ := identity.Get("customer_id").(string)
+		if customerID == "" {
+			return nil, fmt.Errorf("expected identity to contain customer_id")
+		}
+		d.SetId(customerID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getCustomerSettings(ctx context.Context, conn *gofastly.Client, customerID string) (*customerSettings, error) {
+	resp, err := conn.Get(ctx, gofastly.ToSafeURL("customer", customerID), gofastly.CreateRequestOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var settings *customerSettings
+	if err := gofastly.DecodeBodyMap(resp.Body, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// expandCustomerSettings returns the settings to send, which are only those
+// set in the configuration, so that managing one setting never changes the
+// other.
+func expandCustomerSettings(d *schema.ResourceData) *updateCustomerSettingsInput {
+	input := &updateCustomerSettingsInput{}
+	if v := getOptionalBool(d, "force_2fa"); v != nil {
+		input.Force2FA = gofastly.ToPointer(gofastly.Compatibool(*v))
+	}
+	if v := getOptionalBool(d, "force_sso"); v != nil {
+		input.ForceSSO = gofastly.ToPointer(gofastly.Compatibool(*v))
+	}
+	return input
+}
+
+func updateCustomerSettings(ctx context.Context, conn *gofastly.Client, customerID string, d *schema.ResourceData) error {
+	input := expandCustomerSettings(d)
+	if input.Force2FA == nil && input.ForceSSO == nil {
+		return nil
+	}
+	return putCustomerSettings(ctx, conn, customerID, input)
+}
+
+func putCustomerSettings(ctx context.Context, conn *gofastly.Client, customerID string, input *updateCustomerSettingsInput) error {
+	resp, err := conn.PutForm(ctx, gofastly.ToSafeURL("customer", customerID), input, gofastly.CreateRequestOptions())
+	if err != nil {
+		return fmt.Errorf("error updating customer settings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
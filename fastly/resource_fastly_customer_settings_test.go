@@ -0,0 +1,198 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	gofastly "github.com/fastly/go-fastly/v12/fastly"
+)
+
+const fastlyCustomerSettings = "fastly_customer_settings.foo"
+
+// TestAccFastlyCustomerSettings_basic manages the account settings without
+// enabling enforcement, as turning on force_2fa or force_sso would lock out
+// users of the test account. Destroying the resource leaves the settings as
+// they are, so the original ones are put back afterwards.
+func TestAccFastlyCustomerSettings_basic(t *testing.T) {
+	var original testAccCustomerSettingsSnapshot
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			original = testAccSaveCustomerSettings(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(*terraform.State) error {
+			return original.restore()
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerSettingsConfig(false, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFastlyCustomerSettings(false, false),
+					resource.TestCheckResourceAttrSet(
+						fastlyCustomerSettings, "customer_id"),
+					resource.TestCheckResourceAttr(
+						fastlyCustomerSettings, "force_2fa", "false"),
+					resource.TestCheckResourceAttr(
+						fastlyCustomerSettings, "force_sso", "false"),
+				),
+			},
+			{
+				ResourceName:      fastlyCustomerSettings,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestExpandCustomerSettings(t *testing.T) {
+	cases := []struct {
+		name               string
+		force2FA, forceSSO cty.Value
+		want2FA, wantSSO   *bool
+	}{
+		{"only force_2fa", cty.True, cty.NullVal(cty.Bool), gofastly.ToPointer(true), nil},
+		{"only force_sso", cty.NullVal(cty.Bool), cty.False, nil, gofastly.ToPointer(false)},
+		{"both", cty.False, cty.True, gofastly.ToPointer(false), gofastly.ToPointer(true)},
+		{"neither", cty.NullVal(cty.Bool), cty.NullVal(cty.Bool), nil, nil},
+	}
+
+	for _, tc := range cases {
+		d := resourceCustomerSettings().Data(&terraform.InstanceState{
+			ID: "cust123",
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				"customer_id": cty.NullVal(cty.String),
+				"force_2fa":   tc.force2FA,
+				"force_sso":   tc.forceSSO,
+			}),
+		})
+
+		input := expandCustomerSettings(d)
+		if got := compatiboolPointer(input.Force2FA); !equalBoolPointers(got, tc.want2FA) {
+			t.Errorf("%s: force_2fa = %v, want %v", tc.name, formatBoolPointer(got), formatBoolPointer(tc.want2FA))
+		}
+		if got := compatiboolPointer(input.ForceSSO); !equalBoolPointers(got, tc.wantSSO) {
+			t.Errorf("%s: force_sso = %v, want %v", tc.name, formatBoolPointer(got), formatBoolPointer(tc.wantSSO))
+		}
+	}
+}
+
+func compatiboolPointer(v *gofastly.Compatibool) *bool {
+	if v == nil {
+		return nil
+	}
+	return gofastly.ToPointer(bool(*v))
+}
+
+func equalBoolPointers(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func formatBoolPointer(v *bool) string {
+	if v == nil {
+		return "not sent"
+	}
+	return fmt.Sprint(*v)
+}
+
+// testAccCheckFastlyCustomerSettings verifies the settings reported by the API.
+func testAccCheckFastlyCustomerSettings(force2FA, forceSSO bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[fastlyCustomerSettings]
+		if !ok {
+			return fmt.Errorf("not found: %s", fastlyCustomerSettings)
+		}
+
+		conn := testAccProvider.Meta().(*APIClient).conn
+		settings, err := getCustomerSettings(context.TODO(), conn, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting customer settings %s: %s", rs.Primary.ID, err)
+		}
+
+		if got := gofastly.ToValue(settings.Force2FA); got != force2FA {
+			return fmt.Errorf("force_2fa: expected %t, got %t", force2FA, got)
+		}
+		if got := gofastly.ToValue(settings.ForceSSO); got != forceSSO {
+			return fmt.Errorf("force_sso: expected %t, got %t", forceSSO, got)
+		}
+		return nil
+	}
+}
+
+// testAccCustomerSettingsSnapshot holds the settings of the test account
+// before a test changes them.
+type testAccCustomerSettingsSnapshot struct {
+	client     *APIClient
+	customerID string
+	settings   *customerSettings
+}
+
+func testAccSaveCustomerSettings(t *testing.T) testAccCustomerSettingsSnapshot {
+	ctx := context.Background()
+	config := EnvConfig(ctx, "terraform-provider-fastly-user-mgt/acctest")
+	client, diags := config.Client()
+	if diags.HasError() {
+		t.Fatalf("error configuring client: %v", diags)
+	}
+
+	customerID, err := currentCustomerID(ctx, client.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := getCustomerSettings(ctx, client.conn, customerID)
+	if err != nil {
+		t.Fatalf("error getting customer settings %s: %s", customerID, err)
+	}
+	return testAccCustomerSettingsSnapshot{client: client, customerID: customerID, settings: settings}
+}
+
+func (s testAccCustomerSettingsSnapshot) restore() error {
+	if s.client == nil {
+		return nil
+	}
+
+	input := &updateCustomerSettingsInput{}
+	if s.settings.Force2FA != nil {
+		input.Force2FA = gofastly.ToPointer(gofastly.Compatibool(*s.settings.Force2FA))
+	}
+	if s.settings.ForceSSO != nil {
+		input.ForceSSO = gofastly.ToPointer(gofastly.Compatibool(*s.settings.ForceSSO))
+	}
+	if err := putCustomerSettings(context.Background(), s.client.conn, s.customerID, input); err != nil {
+		return fmt.Errorf("error restoring customer settings %s: %s", s.customerID, err)
+	}
+	return nil
+}
+
+func testAccCustomerSettingsConfig(force2FA, forceSSO bool) string {
+	return fmt.Sprintf(`
+resource "fastly_customer_settings" "foo" {
+	force_2fa = %t
+	force_sso = %t
+}`, force2FA, forceSSO)
+}
+
+func TestResourceCustomerSettingsImportIdentity(t *testing.T) {
+	d := schema.TestResourceDataWithIdentityRaw(t, resourceCustomerSettings().SchemaMap(), resourceCustomerSettingsIdentitySchema(), map[string]string{
+		"customer_id": "cust123",
+	})
+
+	result, err := resourceCustomerSettingsImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result[0].Id(); got != "cust123" {
+		t.Errorf("ID = %q, want cust123", got)
+	}
+}
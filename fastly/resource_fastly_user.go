@@ -8,8 +8,11 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	gofastly "github.com/fastly/go-fastly/v12/fastly"
@@ -50,10 +53,19 @@ type invitationResponseData struct {
 	ID         string `json:"id"`
 	Type       string `json:"type"`
 	Attributes struct {
-		Email      string `json:"email"`
-		Role       string `json:"role"`
-		StatusCode int    `json:"status_code"`
+		Email         string   `json:"email"`
+		Role          string   `json:"role"`
+		Roles         []string `json:"roles"`
+		LimitServices bool     `json:"limit_services"`
+		StatusCode    int      `json:"status_code"`
+		CreatedAt     string   `json:"created_at"`
+		ExpiresAt     string   `json:"expires_at"`
 	} `json:"attributes"`
+	Relationships struct {
+		InvitedBy struct {
+			Data *customerData `json:"data"`
+		} `json:"invited_by"`
+	} `json:"relationships"`
 }
 
 type invitationResponse struct {
@@ -64,28 +76,85 @@ type listInvitationsResponse struct {
 	Data []invitationResponseData `json:"data"`
 }
 
-func resourceUser() *schema.Resource {
+// Actions resourceUserCreate can take for a new fastly_user.
+const (
+	userActionAdoptUser       = "adopt_user"
+	userActionAdoptInvitation = "adopt_invitation"
+	userActionInvite          = "invite"
+)
+
+// Values of the computed status of a fastly_user.
+const (
+	userStatusPending = "pending"
+	userStatusActive  = "active"
+	userStatusLocked  = "locked"
+	userStatusExpired = "expired"
+)
+
+// Values of on_destroy, which controls what destroying a fastly_user does
+// to the user.
+const (
+	onDestroyDelete  = "delete"
+	onDestroyLock    = "lock"
+	onDestroyDemote  = "demote"
+	onDestroyAbandon = "abandon"
+
+	// onDestroyDemoteRole is the role given to users with on_destroy = "demote".
+	onDestroyDemoteRole = "user"
+)
+
+// Values of on_existing, which controls what happens when a new fastly_user
+// matches a user or invitation that already exists.
+const (
+	onExistingAdopt             = "adopt"
+	onExistingError             = "error"
+	onExistingAdoptAndReconcile = "adopt_and_reconcile"
+)
+
+// resourceUser returns the fastly_user resource. configured returns the client
+// of the provider instance the resource belongs to, for the parts of the
+// schema that have no access to the provider meta; it may be nil when only
+// the schema is needed.
+func resourceUser(configured func() *APIClient) *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserCreate,
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		CustomizeDiff: resourceUserCustomizeDiff,
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: resourceUserIdentitySchema,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceUserV0Type(),
+				Upgrade: resourceUserStateUpgradeV0,
+				Version: 0,
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
 			"login": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The email address, which is the login name, of the User",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The email address, which is the login name, of the User. Differences ignored by the provider's login normalization rules do not cause a diff",
+				DiffSuppressFunc: suppressEquivalentLogin(configured),
 			},
 
 			"name": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The real life name of the user",
+				Optional:    true,
+				Computed:    true,
+				Description: "The real life name of the user. When not set, the name the user chose when accepting the invitation is kept",
 			},
 
 			"role": {
@@ -100,7 +169,7 @@ func resourceUser() *schema.Resource {
 			"invitation_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The ID of the pending invitation (only set while invitation is pending)",
+				Description: "The ID of the pending invitation (only set while invitation is pending). The resource ID is the login, which does not change when the invitation is accepted",
 			},
 
 			// Tracks if user has been created (invitation accepted)
@@ -109,32 +178,295 @@ func resourceUser() *schema.Resource {
 				Computed:    true,
 				Description: "The actual user ID (set once invitation is accepted)",
 			},
+
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the user: `pending` while the invitation has not been accepted, `expired` if the invitation expired, `active` once accepted, or `locked` if the account is locked",
+			},
+
+			"locked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user account is locked. Set to `true` to suspend the user's access without deleting them, or `false` to unlock them. When not set, the lock status is only read. Users with a pending invitation cannot be locked",
+			},
+
+			"two_factor_auth_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has two-factor authentication enabled",
+			},
+
+			"limit_services": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has limited access to services",
+			},
+
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the user was created (empty while the invitation is pending)",
+			},
+
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the user was last updated (empty while the invitation is pending)",
+			},
+
+			"require_new_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user must set a new password at their next login. When not set, the value is only read. While the invitation is pending, the value is stored, and it is applied by the first apply after the invitation is accepted",
+			},
+
+			"password_reset_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value; changing it to a new non-empty value sends the user a password reset email. Setting it when the resource is created does not send an email",
+			},
+
+			"wait_for_acceptance": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether creating the resource waits until the invitation is accepted, so that `user_id` is known within the same apply. The wait is bounded by the `create` timeout (default 30 minutes). Default: `false`",
+			},
+
+			"reissue_invitation_on_role_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether changing the role while the invitation is still pending sends a new invitation with the new role and then revokes the old one. When `false`, such changes fail until the invitation is accepted. Default: `true`",
+			},
+
+			"on_existing": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          onExistingAdopt,
+				Description:      "What to do when creating the resource finds an existing user or pending invitation for the login. `adopt` (the default) manages it as-is, `error` fails instead, and `adopt_and_reconcile` manages it and immediately applies the configured name and role (re-sending the invitation if its role differs)",
+				ValidateDiagFunc: validateOnExisting(),
+			},
+
+			"on_destroy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          onDestroyDelete,
+				Description:      "What destroying the resource does to the user. `delete` (the default) deletes the user, `lock` locks the account, `demote` changes the role to `user`, and `abandon` only removes the resource from state. A pending invitation is revoked unless this is `abandon`",
+				ValidateDiagFunc: validateOnDestroy(),
+			},
+
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to prevent the resource from being destroyed. Must be set to `false` and applied before the resource can be destroyed. Default: `false`",
+			},
+
+			"customer_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the customer account the user belongs to",
+			},
+
+			"planned_action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "What creating the resource does, as determined during plan: `adopt_user` when a user with this login already exists, `adopt_invitation` when an invitation is already pending, or `invite` when a new invitation email will be sent",
+			},
+
+			"adoption_warning": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Set during plan when creating the resource will adopt an existing user or invitation whose name or role differs from the configuration, e.g. `will adopt existing user 123 with role superuser, config says engineer`. Empty otherwise",
+			},
 		},
 	}
 }
 
+// resourceUserCustomizeDiff looks up existing users and invitations while
+// planning a new fastly_user, so the plan shows whether the apply will adopt
+// an existing user or invitation or send a new invitation email.
+func resourceUserCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() != "" || !d.NewValueKnown("login") {
+		return nil
+	}
+
+	// The provider may not be configured yet, e.g. when its arguments
+	// depend on values only known after apply.
+	client, ok := meta.(*APIClient)
+	if !ok || client == nil {
+		return nil
+	}
+
+	login := d.Get("login").(string)
+	name := d.Get("name").(string)
+	role := d.Get("role").(string)
+	onExisting := d.Get("on_existing").(string)
+
+	// The warning compares the configuration with what already exists, so
+	// it stays unknown until the values it compares are known.
+	setAdoptionWarning := func(mismatch func() string) error {
+		if !d.NewValueKnown("name") || !d.NewValueKnown("role") || !d.NewValueKnown("on_existing") {
+			return nil
+		}
+		return d.SetNew("adoption_warning", adoptionWarning(mismatch(), onExisting))
+	}
+
+	existingUser, err := findUserByLogin(ctx, client, login)
+	if err != nil {
+		return fmt.Errorf("error checking for existing user: %w", err)
+	}
+	if existingUser != nil {
+		if onExisting == onExistingError {
+			return fmt.Errorf("user %s already exists (user ID %s) and on_existing is %q", login, gofastly.ToValue(existingUser.UserID), onExisting)
+		}
+		if err := setAdoptionWarning(func() string { return adoptUserMismatch(existingUser, name, role) }); err != nil {
+			return err
+		}
+		return d.SetNew("planned_action", userActionAdoptUser)
+	}
+
+	existingInvitation, err := findInvitationByEmail(ctx, client, login)
+	if err != nil {
+		return fmt.Errorf("error checking for existing invitation: %w", err)
+	}
+	if existingInvitation != nil {
+		if onExisting == onExistingError {
+			return fmt.Errorf("an invitation for %s is already pending (invitation ID %s) and on_existing is %q", login, existingInvitation.ID, onExisting)
+		}
+		if err := setAdoptionWarning(func() string { return adoptInvitationMismatch(existingInvitation, role) }); err != nil {
+			return err
+		}
+		return d.SetNew("planned_action", userActionAdoptInvitation)
+	}
+
+	if err := d.SetNew("adoption_warning", ""); err != nil {
+		return err
+	}
+	return d.SetNew("planned_action", userActionInvite)
+}
+
+// adoptionWarning returns the value of adoption_warning for a mismatch
+// described by adoptUserMismatch or adoptInvitationMismatch.
+func adoptionWarning(mismatch, onExisting string) string {
+	if mismatch != "" && onExisting == onExistingAdoptAndReconcile {
+		return mismatch + " (will be reconciled to match the config)"
+	}
+	return mismatch
+}
+
+// adoptUserMismatch describes how an existing user differs from the
+// configuration, or returns an empty string if it matches. An empty name
+// means the name is not configured.
+func adoptUserMismatch(u *gofastly.User, name, role string) string {
+	var diffs []string
+	if r := gofastly.ToValue(u.Role); r != role {
+		diffs = append(diffs, fmt.Sprintf("role %s, config says %s", r, role))
+	}
+	if n := gofastly.ToValue(u.Name); name != "" && n != name {
+		diffs = append(diffs, fmt.Sprintf("name %q, config says %q", n, name))
+	}
+	if len(diffs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("will adopt existing user %s with %s", gofastly.ToValue(u.UserID), strings.Join(diffs, " and "))
+}
+
+// adoptInvitationMismatch describes how an existing invitation differs from
+// the configuration, or returns an empty string if it matches.
+func adoptInvitationMismatch(inv *Invitation, role string) string {
+	if inv.Role == role {
+		return ""
+	}
+	return fmt.Sprintf("will adopt existing invitation %s with role %s, config says %s", inv.ID, inv.Role, role)
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*APIClient)
 	conn := client.conn
 	login := d.Get("login").(string)
+	name := d.Get("name").(string)
 	role := d.Get("role").(string)
+	onExisting := d.Get("on_existing").(string)
+
+	customerID, err := currentCustomerID(ctx, conn)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("customer_id", customerID); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// First, check if user already exists (e.g., was invited outside of Terraform)
-	existingUser, err := findUserByLogin(ctx, conn, login)
+	existingUser, err := findUserByLogin(ctx, client, login)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error checking for existing user: %w", err))
 	}
 
 	if existingUser != nil {
+		userID := gofastly.ToValue(existingUser.UserID)
+		if onExisting == onExistingError {
+			return diag.Errorf("user %s already exists (user ID %s) and on_existing is %q", login, userID, onExisting)
+		}
+
 		// User already exists, just import them
-		d.SetId(gofastly.ToValue(existingUser.UserID))
-		if err := d.Set("user_id", gofastly.ToValue(existingUser.UserID)); err != nil {
+		d.SetId(login)
+		if err := setUserIdentity(d); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("user_id", userID); err != nil {
 			return diag.FromErr(err)
 		}
 		if err := d.Set("invitation_id", ""); err != nil {
 			return diag.FromErr(err)
 		}
-		return resourceUserRead(ctx, d, meta)
+		if err := d.Set("planned_action", userActionAdoptUser); err != nil {
+			return diag.FromErr(err)
+		}
+
+		var diags diag.Diagnostics
+		msg := adoptUserMismatch(existingUser, name, role)
+		if err := d.Set("adoption_warning", adoptionWarning(msg, onExisting)); err != nil {
+			return diag.FromErr(err)
+		}
+		if msg != "" {
+			if onExisting == onExistingAdoptAndReconcile {
+				_, err := conn.UpdateUser(ctx, &gofastly.UpdateUserInput{
+					UserID: userID,
+					Name:   gofastly.NullString(name),
+					Role:   gofastly.ToPointer(role),
+				})
+				if err != nil {
+					return diag.FromErr(fmt.Errorf("error reconciling adopted user %s: %w", userID, err))
+				}
+				log.Printf("[DEBUG] Reconciled adopted user %s: %s", login, msg)
+			} else {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Adopted existing user %s", login),
+					Detail:   msg,
+				})
+			}
+		}
+		if locked := getOptionalBool(d, "locked"); locked != nil && *locked != gofastly.ToValue(existingUser.Locked) {
+			if err := setUserLocked(ctx, conn, userID, *locked); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if rnp := getOptionalBool(d, "require_new_password"); rnp != nil && *rnp != gofastly.ToValue(existingUser.RequireNewPassword) {
+			if err := setUserRequireNewPassword(ctx, conn, userID, *rnp); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return append(diags, resourceUserRead(ctx, d, meta)...)
+	}
+
+	if locked := getOptionalBool(d, "locked"); locked != nil && *locked {
+		return diag.Errorf("cannot lock %s as the user does not exist yet; remove locked or wait for the invitation to be accepted", login)
 	}
 
 	// Check if there's already a pending invitation for this email
@@ -144,48 +476,166 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) d
 	}
 
 	if existingInvitation != nil {
+		if onExisting == onExistingError {
+			return diag.Errorf("an invitation for %s is already pending (invitation ID %s) and on_existing is %q", login, existingInvitation.ID, onExisting)
+		}
+
+		invitationID := existingInvitation.ID
+		adopted := existingInvitation
+		var diags diag.Diagnostics
+		var reissueErr error
+		msg := adoptInvitationMismatch(existingInvitation, role)
+		if err := d.Set("adoption_warning", adoptionWarning(msg, onExisting)); err != nil {
+			return diag.FromErr(err)
+		}
+		if msg != "" {
+			if onExisting == onExistingAdoptAndReconcile {
+				// Invitations cannot be updated, so send a new one with
+				// the configured role instead.
+				invitation, err := reissueInvitation(ctx, client, invitationID, login, role)
+				if invitation == nil {
+					return diag.FromErr(fmt.Errorf("error reconciling adopted invitation %s: %w", invitationID, err))
+				}
+				reissueErr = err
+				log.Printf("[DEBUG] Reissued invitation for %s as %s: %s", login, invitation.Data.ID, msg)
+				invitationID = invitation.Data.ID
+				adopted = flattenInvitation(invitation.Data)
+			} else {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Adopted existing invitation for %s", login),
+					Detail:   msg,
+				})
+			}
+		}
+
 		// Invitation already exists, track it
-		d.SetId(existingInvitation.ID)
-		if err := d.Set("invitation_id", existingInvitation.ID); err != nil {
+		d.SetId(login)
+		if err := setUserIdentity(d); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("invitation_id", invitationID); err != nil {
 			return diag.FromErr(err)
 		}
 		if err := d.Set("user_id", ""); err != nil {
 			return diag.FromErr(err)
 		}
-		log.Printf("[DEBUG] Found existing invitation for %s: %s", login, existingInvitation.ID)
-		return nil
-	}
+		if err := d.Set("planned_action", userActionAdoptInvitation); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setInvitationAttributes(d, adopted); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[DEBUG] Found existing invitation for %s: %s", login, invitationID)
 
-	// No existing user or invitation - create a new invitation
-	currentUser, err := conn.GetCurrentUser(ctx)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error getting current user: %w", err))
+		if reissueErr != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error reconciling adopted invitation: %w", reissueErr))...)
+		}
+		if d.Get("wait_for_acceptance").(bool) {
+			return append(diags, waitForInvitationAcceptance(ctx, d, meta)...)
+		}
+		return diags
 	}
 
-	customerID := gofastly.ToValue(currentUser.CustomerID)
-
+	// No existing user or invitation - create a new invitation
 	invitation, err := createInvitation(ctx, client, login, role, customerID)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating invitation: %w", err))
 	}
 
-	// Set the invitation ID as the resource ID initially
-	d.SetId(invitation.Data.ID)
+	// The login is used as the resource ID, as it stays the same when the
+	// invitation is accepted or reissued.
+	d.SetId(login)
+	if err := setUserIdentity(d); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("invitation_id", invitation.Data.ID); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("user_id", ""); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("planned_action", userActionInvite); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("adoption_warning", ""); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setInvitationAttributes(d, flattenInvitation(invitation.Data)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Printf("[DEBUG] Created invitation for %s: %s", login, invitation.Data.ID)
 
+	if d.Get("wait_for_acceptance").(bool) {
+		return waitForInvitationAcceptance(ctx, d, meta)
+	}
+
 	return nil
 }
 
+// waitForInvitationAcceptance polls until the invitee has accepted the
+// invitation and then reads the new user into state, so that user_id is
+// known by the end of the apply.
+func waitForInvitationAcceptance(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*APIClient)
+	login := d.Get("login").(string)
+
+	conf := &retry.StateChangeConf{
+		Pending:    []string{userStatusPending},
+		Target:     []string{userStatusActive},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 5 * time.Second,
+		Refresh: func() (any, string, error) {
+			u, err := findUserByLogin(ctx, client, login)
+			if err != nil {
+				return nil, "", err
+			}
+			if u == nil {
+				return login, userStatusPending, nil
+			}
+			return u, userStatusActive, nil
+		},
+	}
+
+	log.Printf("[DEBUG] Waiting for %s to accept invitation %s", login, d.Get("invitation_id").(string))
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for %s to accept the invitation: %w", login, err))
+	}
+
+	// resourceUserRead notices the accepted invitation and moves the
+	// resource over to the new user.
+	return resourceUserRead(ctx, d, meta)
+}
+
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing User Configuration for (%s)", d.Id())
 	client := meta.(*APIClient)
+
+	// State from before customer_id was tracked
+	if d.Get("customer_id").(string) == "" {
+		customerID, err := currentCustomerID(ctx, client.conn)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("customer_id", customerID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	diags := readUser(ctx, d, client)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	if err := setUserIdentity(d); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// readUser refreshes the state of a user or pending invitation.
+func readUser(ctx context.Context, d *schema.ResourceData, client *APIClient) diag.Diagnostics {
 	conn := client.conn
 
 	userID := d.Get("user_id").(string)
@@ -206,20 +656,8 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) dia
 			return diag.FromErr(err)
 		}
 
-		if u.Login != nil {
-			if err := d.Set("login", u.Login); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-		if u.Name != nil {
-			if err := d.Set("name", u.Name); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-		if u.Role != nil {
-			if err := d.Set("role", u.Role); err != nil {
-				return diag.FromErr(err)
-			}
+		if err := setUserAttributes(d, u); err != nil {
+			return diag.FromErr(err)
 		}
 		return nil
 	}
@@ -228,15 +666,18 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) dia
 	// or if the user has accepted it
 	if invitationID != "" {
 		// First, check if user now exists (invitation was accepted)
-		existingUser, err := findUserByLogin(ctx, conn, login)
+		existingUser, err := findUserByLogin(ctx, client, login)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("error checking for user: %w", err))
 		}
 
 		if existingUser != nil {
-			// User has accepted the invitation! Update state to reflect this
+			// User has accepted the invitation! Update state to reflect
+			// this. The user chooses their own name when accepting it, so
+			// a configured name that differs shows up as a diff and is
+			// applied by resourceUserUpdate.
 			newUserID := gofastly.ToValue(existingUser.UserID)
-			d.SetId(newUserID)
+
 			if err := d.Set("user_id", newUserID); err != nil {
 				return diag.FromErr(err)
 			}
@@ -245,103 +686,393 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) dia
 			}
 
 			// Read the rest of user attributes
-			if existingUser.Login != nil {
-				if err := d.Set("login", existingUser.Login); err != nil {
-					return diag.FromErr(err)
-				}
-			}
-			if existingUser.Name != nil {
-				if err := d.Set("name", existingUser.Name); err != nil {
-					return diag.FromErr(err)
-				}
-			}
-			if existingUser.Role != nil {
-				if err := d.Set("role", existingUser.Role); err != nil {
-					return diag.FromErr(err)
-				}
+			if err := setUserAttributes(d, existingUser); err != nil {
+				return diag.FromErr(err)
 			}
 
 			log.Printf("[DEBUG] User %s accepted invitation, transitioning to user_id %s", login, newUserID)
 			return nil
 		}
 
-		// Check if the invitation still exists
+		// Check if the invitation still exists. An inactive invitation was
+		// accepted or revoked without a user to show for it, so it counts
+		// as gone too.
 		invitation, err := getInvitation(ctx, client, invitationID)
+		if err == nil && invitation.Status() != "pending" && invitation.Status() != "expired" {
+			log.Printf("[DEBUG] Invitation %s for %s is %s", invitationID, login, invitation.Status())
+			err = fmt.Errorf("invitation %s is %s", invitationID, invitation.Status())
+		}
 		if err != nil {
-			// Invitation might have been deleted or expired
-			// This is not an error - just means we need to recreate it
-			log.Printf("[DEBUG] Invitation %s no longer exists, will recreate on next apply", invitationID)
-			d.SetId("")
-			return nil
+			// The invitation may have been replaced, e.g. by the
+			// fastly_resend_invitation action, so follow it by login.
+			invitation, err = findInvitationByEmail(ctx, client, login)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error checking for invitation: %w", err))
+			}
+			if invitation == nil {
+				// Invitation might have been deleted or expired
+				// This is not an error - just means we need to recreate it
+				log.Printf("[DEBUG] Invitation %s no longer exists, will recreate on next apply", invitationID)
+				d.SetId("")
+				return nil
+			}
+
+			log.Printf("[DEBUG] Invitation %s for %s was replaced by %s", invitationID, login, invitation.ID)
+			if err := d.Set("invitation_id", invitation.ID); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 
 		// Invitation still pending - this is fine, keep the state as-is
 		log.Printf("[DEBUG] Invitation %s still pending for %s (status_code: %d)",
 			invitationID, login, invitation.StatusCode)
+		if err := setInvitationAttributes(d, invitation); err != nil {
+			return diag.FromErr(err)
+		}
 		return nil
 	}
 
-	// No user_id or invitation_id - this might be an import by user ID
-	// Try to read as a user first
-	u, err := conn.GetUser(ctx, &gofastly.GetUserInput{
-		UserID: d.Id(),
-	})
+	// No user_id or invitation_id, e.g. after a failed create - look the
+	// user up by login instead
+	u, err := findUserByLogin(ctx, client, login)
 	if err != nil {
-		if httpErr, ok := err.(*gofastly.HTTPError); ok && httpErr.IsNotFound() {
-			d.SetId("")
-			return nil
-		}
-		return diag.FromErr(err)
+		return diag.FromErr(fmt.Errorf("error checking for user: %w", err))
+	}
+	if u == nil {
+		log.Printf("[WARN] No user found for %s, removing from state", login)
+		d.SetId("")
+		return nil
 	}
 
 	// Successfully read as a user - update state accordingly
-	if err := d.Set("user_id", d.Id()); err != nil {
+	if err := d.Set("user_id", gofastly.ToValue(u.UserID)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("invitation_id", ""); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := setUserAttributes(d, u); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceUserIdentitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"customer_id": {
+			Type:              schema.TypeString,
+			OptionalForImport: true,
+			Description:       "The ID of the customer account the user belongs to. Defaults to the account that owns the API key",
+		},
+		"login": {
+			Type:              schema.TypeString,
+			RequiredForImport: true,
+			Description:       "The login (email address) of the user",
+		},
+	}
+}
+
+// setUserIdentity sets the resource identity from state. The login is taken
+// from the resource ID, which keeps the spelling the resource was created
+// with even if the API reports the login differently.
+func setUserIdentity(d *schema.ResourceData) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	if err := identity.Set("customer_id", d.Get("customer_id").(string)); err != nil {
+		return err
+	}
+	return identity.Set("login", d.Id())
+}
+
+// setUserAttributes copies the attributes of an existing user into state.
+func setUserAttributes(d *schema.ResourceData, u *gofastly.User) error {
 	if u.Login != nil {
 		if err := d.Set("login", u.Login); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 	}
 	if u.Name != nil {
 		if err := d.Set("name", u.Name); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 	}
 	if u.Role != nil {
 		if err := d.Set("role", u.Role); err != nil {
-			return diag.FromErr(err)
+			return err
+		}
+	}
+
+	status := userStatusActive
+	if gofastly.ToValue(u.Locked) {
+		status = userStatusLocked
+	}
+
+	var createdAt, updatedAt string
+	if u.CreatedAt != nil {
+		createdAt = u.CreatedAt.String()
+	}
+	if u.UpdatedAt != nil {
+		updatedAt = u.UpdatedAt.String()
+	}
+
+	attrs := map[string]any{
+		"status":                  status,
+		"locked":                  gofastly.ToValue(u.Locked),
+		"two_factor_auth_enabled": gofastly.ToValue(u.TwoFactorAuthEnabled),
+		"limit_services":          gofastly.ToValue(u.LimitServices),
+		"require_new_password":    gofastly.ToValue(u.RequireNewPassword),
+		"created_at":              createdAt,
+		"updated_at":              updatedAt,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setInvitationAttributes sets the user attributes that are known while the
+// invitation is pending or expired. Any other invitation is not tracked.
+func setInvitationAttributes(d *schema.ResourceData, inv *Invitation) error {
+	var status string
+	switch inv.Status() {
+	case "pending":
+		status = userStatusPending
+	case "expired":
+		status = userStatusExpired
+	default:
+		return fmt.Errorf("invitation %s for %s is %s, not pending", inv.ID, inv.Email, inv.Status())
+	}
+
+	attrs := map[string]any{
+		"status":                  status,
+		"locked":                  false,
+		"two_factor_auth_enabled": false,
+		"limit_services":          inv.LimitServices,
+		"created_at":              "",
+		"updated_at":              "",
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceUserImport accepts import IDs of the form user/<user_id>,
+// invitation/<invitation_id> or login/<email>. A bare ID is treated as a
+// login if it contains "@", as it does when it is the resource ID, and as a
+// user ID otherwise for compatibility with earlier versions.
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	client := meta.(*APIClient)
+
+	// Import by identity, which has no ID
+	if d.Id() == "" {
+		if err := importUserIdentityState(ctx, client, d); err != nil {
+			return nil, err
 		}
+		return resourceUserImportDefaults(d)
 	}
 
+	kind, value, ok := strings.Cut(d.Id(), "/")
+	switch {
+	case ok:
+	case strings.Contains(d.Id(), "@"):
+		kind, value = "login", d.Id()
+	default:
+		kind, value = "user", d.Id()
+	}
+	if value == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected user/<user_id>, invitation/<invitation_id> or login/<email>", d.Id())
+	}
+
+	var err error
+	switch kind {
+	case "user":
+		var u *gofastly.User
+		u, err = client.conn.GetUser(ctx, &gofastly.GetUserInput{UserID: value})
+		if err == nil {
+			err = importUserState(d, value, gofastly.ToValue(u.Login))
+		}
+	case "invitation":
+		var inv *Invitation
+		inv, err = getInvitation(ctx, client, value)
+		if err == nil {
+			err = importInvitationState(d, inv)
+		}
+	case "login":
+		err = importLoginState(ctx, client, d, value)
+	default:
+		err = fmt.Errorf("invalid import ID %q, expected user/<user_id>, invitation/<invitation_id> or login/<email>", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceUserImportDefaults(d)
+}
+
+// resourceUserImportDefaults sets the arguments with defaults, which are not
+// read back from the API, to avoid a diff right after import.
+func resourceUserImportDefaults(d *schema.ResourceData) ([]*schema.ResourceData, error) {
+	if err := d.Set("on_existing", onExistingAdopt); err != nil {
+		return nil, err
+	}
+	if err := d.Set("reissue_invitation_on_role_change", true); err != nil {
+		return nil, err
+	}
+	if err := d.Set("on_destroy", onDestroyDelete); err != nil {
+		return nil, err
+	}
+	if err := d.Set("wait_for_acceptance", false); err != nil {
+		return nil, err
+	}
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func importUserIdentityState(ctx context.Context, client *APIClient, d *schema.ResourceData) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("error getting identity: %w", err)
+	}
+
+	login, _ := identity.Get("login").(string)
+	if login == "" {
+		return fmt.Errorf("expected identity to contain login")
+	}
+
+	customerID, err := currentCustomerID(ctx, client.conn)
+	if err != nil {
+		return err
+	}
+	if v, _ := identity.Get("customer_id").(string); v != "" && v != customerID {
+		return fmt.Errorf("identity customer_id %s does not match the customer %s of the API key", v, customerID)
+	}
+	if err := d.Set("customer_id", customerID); err != nil {
+		return err
+	}
+
+	if err := importLoginState(ctx, client, d, login); err != nil {
+		return err
+	}
+
+	// Keep the login exactly as given in the identity
+	d.SetId(login)
 	return nil
 }
 
+func importLoginState(ctx context.Context, client *APIClient, d *schema.ResourceData, login string) error {
+	existingUser, err := findUserByLogin(ctx, client, login)
+	if err != nil {
+		return fmt.Errorf("error checking for existing user: %w", err)
+	}
+	if existingUser != nil {
+		return importUserState(d, gofastly.ToValue(existingUser.UserID), gofastly.ToValue(existingUser.Login))
+	}
+
+	existingInvitation, err := findInvitationByEmail(ctx, client, login)
+	if err != nil {
+		return fmt.Errorf("error checking for existing invitation: %w", err)
+	}
+	if existingInvitation != nil {
+		return importInvitationState(d, existingInvitation)
+	}
+
+	return fmt.Errorf("no user or pending invitation found for login %s", login)
+}
+
+func importUserState(d *schema.ResourceData, userID, login string) error {
+	d.SetId(login)
+	if err := d.Set("user_id", userID); err != nil {
+		return err
+	}
+	if err := d.Set("invitation_id", ""); err != nil {
+		return err
+	}
+	return d.Set("login", login)
+}
+
+func importInvitationState(d *schema.ResourceData, inv *Invitation) error {
+	d.SetId(inv.Email)
+	if err := d.Set("invitation_id", inv.ID); err != nil {
+		return err
+	}
+	if err := d.Set("user_id", ""); err != nil {
+		return err
+	}
+	if err := d.Set("login", inv.Email); err != nil {
+		return err
+	}
+	return d.Set("role", inv.Role)
+}
+
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	conn := meta.(*APIClient).conn
 
 	userID := d.Get("user_id").(string)
 
-	// Can only update if the user exists (invitation has been accepted)
+	// Can only update the user once it exists (invitation has been accepted)
 	if userID == "" {
-		// Invitation is still pending - we can't update name/role yet
-		// The role was set in the invitation, so changes would need to
-		// delete and recreate the invitation
-		if d.HasChanges("name", "role") {
-			return diag.Errorf("cannot update user while invitation is still pending; please wait for the user to accept the invitation")
+		if d.HasChange("locked") && d.Get("locked").(bool) {
+			return diag.Errorf("cannot lock the user while the invitation is still pending; destroy the resource to revoke the invitation instead")
 		}
-		return nil
+
+		var diags diag.Diagnostics
+		if d.HasChange("password_reset_trigger") && d.Get("password_reset_trigger").(string) != "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Password reset not sent",
+				Detail:   fmt.Sprintf("%s has not accepted the invitation yet, so there is no password to reset", d.Get("login").(string)),
+			})
+		}
+
+		// Name changes are only stored; once the invitation is accepted,
+		// the next plan shows them as a diff against the name the user
+		// chose. The role is part of the invitation, so changing it means
+		// sending a new invitation.
+		if d.HasChange("role") {
+			if !d.Get("reissue_invitation_on_role_change").(bool) {
+				return diag.Errorf("cannot change the role while the invitation is still pending; please wait for the user to accept the invitation or set reissue_invitation_on_role_change")
+			}
+
+			client := meta.(*APIClient)
+			login := d.Get("login").(string)
+			invitation, reissueErr := reissueInvitation(ctx, client, d.Get("invitation_id").(string), login, d.Get("role").(string))
+			if invitation == nil {
+				return diag.FromErr(fmt.Errorf("error reissuing invitation: %w", reissueErr))
+			}
+
+			// Record the new invitation even if the old one could not be
+			// revoked, so the state never points to a replaced invitation.
+			if err := d.Set("invitation_id", invitation.Data.ID); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := setInvitationAttributes(d, flattenInvitation(invitation.Data)); err != nil {
+				return diag.FromErr(err)
+			}
+			if reissueErr != nil {
+				return append(diags, diag.FromErr(fmt.Errorf("error reissuing invitation: %w", reissueErr))...)
+			}
+			log.Printf("[DEBUG] Reissued invitation for %s with role %s: %s", login, d.Get("role").(string), invitation.Data.ID)
+		}
+		return diags
 	}
 
 	// Update Name and/or Role.
 	if d.HasChanges("name", "role") {
 		_, err := conn.UpdateUser(ctx, &gofastly.UpdateUserInput{
 			UserID: userID,
-			Name:   gofastly.ToPointer(d.Get("name").(string)),
+			Name:   gofastly.NullString(d.Get("name").(string)),
 			Role:   gofastly.ToPointer(d.Get("role").(string)),
 		})
 		if err != nil {
@@ -349,18 +1080,110 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta any) d
 		}
 	}
 
+	if d.HasChange("locked") {
+		if err := setUserLocked(ctx, conn, userID, d.Get("locked").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("require_new_password") {
+		if err := setUserRequireNewPassword(ctx, conn, userID, d.Get("require_new_password").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("password_reset_trigger") && d.Get("password_reset_trigger").(string) != "" {
+		login := d.Get("login").(string)
+		if err := conn.ResetUserPassword(ctx, &gofastly.ResetUserPasswordInput{Login: login}); err != nil {
+			return diag.FromErr(fmt.Errorf("error requesting password reset for %s: %w", login, err))
+		}
+		log.Printf("[DEBUG] Requested password reset for %s", login)
+	}
+
 	return resourceUserRead(ctx, d, meta)
 }
 
+// updateUserFlagsInput is the form body of PUT /user/{user_id} for the
+// attributes gofastly.UpdateUserInput does not support.
+type updateUserFlagsInput struct {
+	Locked             *gofastly.Compatibool `url:"locked,omitempty"`
+	RequireNewPassword *gofastly.Compatibool `url:"require_new_password,omitempty"`
+}
+
+func updateUserFlags(ctx context.Context, conn *gofastly.Client, userID string, input *updateUserFlagsInput) error {
+	resp, err := conn.PutForm(ctx, gofastly.ToSafeURL("user", userID), input, gofastly.CreateRequestOptions())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func setUserLocked(ctx context.Context, conn *gofastly.Client, userID string, locked bool) error {
+	err := updateUserFlags(ctx, conn, userID, &updateUserFlagsInput{
+		Locked: gofastly.ToPointer(gofastly.Compatibool(locked)),
+	})
+	if err != nil {
+		action := "unlocking"
+		if locked {
+			action = "locking"
+		}
+		return fmt.Errorf("error %s user %s: %w", action, userID, err)
+	}
+
+	log.Printf("[DEBUG] Set locked=%t on user %s", locked, userID)
+	return nil
+}
+
+func setUserRequireNewPassword(ctx context.Context, conn *gofastly.Client, userID string, require bool) error {
+	err := updateUserFlags(ctx, conn, userID, &updateUserFlagsInput{
+		RequireNewPassword: gofastly.ToPointer(gofastly.Compatibool(require)),
+	})
+	if err != nil {
+		return fmt.Errorf("error setting require_new_password on user %s: %w", userID, err)
+	}
+
+	log.Printf("[DEBUG] Set require_new_password=%t on user %s", require, userID)
+	return nil
+}
+
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*APIClient)
 	conn := client.conn
 
 	userID := d.Get("user_id").(string)
 	invitationID := d.Get("invitation_id").(string)
+	onDestroy := d.Get("on_destroy").(string)
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("cannot destroy fastly_user %s: deletion_protection is enabled; set deletion_protection = false and apply before destroying", d.Get("login").(string))
+	}
+
+	if onDestroy == onDestroyAbandon {
+		log.Printf("[DEBUG] Abandoning fastly_user %s (user_id: %q, invitation_id: %q)", d.Get("login").(string), userID, invitationID)
+		return nil
+	}
 
-	// If there's a user, delete the user
+	// If there's a user, delete, lock or demote the user
 	if userID != "" {
+		switch onDestroy {
+		case onDestroyLock:
+			if err := setUserLocked(ctx, conn, userID, true); err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		case onDestroyDemote:
+			_, err := conn.UpdateUser(ctx, &gofastly.UpdateUserInput{
+				UserID: userID,
+				Role:   gofastly.ToPointer(onDestroyDemoteRole),
+			})
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error demoting user %s: %w", userID, err))
+			}
+			return nil
+		}
+
 		err := conn.DeleteUser(ctx, &gofastly.DeleteUserInput{
 			UserID: userID,
 		})
@@ -374,7 +1197,8 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta any) d
 		return nil
 	}
 
-	// If there's a pending invitation, delete it
+	// If there's a pending invitation, delete it. There is nothing to lock
+	// or demote yet, so revoking the invitation is the closest equivalent.
 	if invitationID != "" {
 		err := deleteInvitation(ctx, client, invitationID)
 		if err != nil {
@@ -388,7 +1212,8 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta any) d
 }
 
 // Helper function to find a user by their login email
-func findUserByLogin(ctx context.Context, conn *gofastly.Client, login string) (*gofastly.User, error) {
+func findUserByLogin(ctx context.Context, client *APIClient, login string) (*gofastly.User, error) {
+	conn := client.conn
 	currentUser, err := conn.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, err
@@ -402,7 +1227,7 @@ func findUserByLogin(ctx context.Context, conn *gofastly.Client, login string) (
 	}
 
 	for _, u := range users {
-		if gofastly.ToValue(u.Login) == login {
+		if client.loginNormalization.Equal(gofastly.ToValue(u.Login), login) {
 			return u, nil
 		}
 	}
@@ -412,31 +1237,97 @@ func findUserByLogin(ctx context.Context, conn *gofastly.Client, login string) (
 
 // Invitation represents a pending invitation
 type Invitation struct {
-	ID         string
-	Email      string
-	Role       string
-	StatusCode int
+	ID            string
+	Email         string
+	Role          string
+	Roles         []string
+	LimitServices bool
+	StatusCode    int
+	CreatedAt     string
+	ExpiresAt     string
+	InvitedBy     string
 }
 
-// Helper function to find an invitation by email
+// Invitation status codes as reported by the API.
+const (
+	invitationStatusInactive = 0
+	invitationStatusActive   = 1
+)
+
+// Status returns a human-readable status for the invitation, derived from
+// its status code and expiry time.
+func (inv *Invitation) Status() string {
+	if t, err := time.Parse(time.RFC3339, inv.ExpiresAt); err == nil && time.Now().After(t) {
+		return "expired"
+	}
+
+	switch inv.StatusCode {
+	case invitationStatusActive:
+		return "pending"
+	case invitationStatusInactive:
+		return "inactive"
+	default:
+		return "unknown"
+	}
+}
+
+// Age returns how long ago the invitation was created, or zero if the
+// creation time is unknown.
+func (inv *Invitation) Age() time.Duration {
+	t, err := time.Parse(time.RFC3339, inv.CreatedAt)
+	if err != nil {
+		return 0
+	}
+	return time.Since(t)
+}
+
+func flattenInvitation(inv invitationResponseData) *Invitation {
+	invitation := &Invitation{
+		ID:            inv.ID,
+		Email:         inv.Attributes.Email,
+		Role:          inv.Attributes.Role,
+		Roles:         inv.Attributes.Roles,
+		LimitServices: inv.Attributes.LimitServices,
+		StatusCode:    inv.Attributes.StatusCode,
+		CreatedAt:     inv.Attributes.CreatedAt,
+		ExpiresAt:     inv.Attributes.ExpiresAt,
+	}
+	if inv.Relationships.InvitedBy.Data != nil {
+		invitation.InvitedBy = inv.Relationships.InvitedBy.Data.ID
+	}
+	return invitation
+}
+
+// findInvitationByEmail returns the pending invitation sent to email, or nil
+// if there is none.
 func findInvitationByEmail(ctx context.Context, client *APIClient, email string) (*Invitation, error) {
-	invitations, err := listInvitations(ctx, client)
+	resp, err := listInvitations(ctx, client)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, inv := range invitations.Data {
-		if inv.Attributes.Email == email {
-			return &Invitation{
-				ID:         inv.ID,
-				Email:      inv.Attributes.Email,
-				Role:       inv.Attributes.Role,
-				StatusCode: inv.Attributes.StatusCode,
-			}, nil
-		}
+	invitations := make([]*Invitation, 0, len(resp.Data))
+	for _, v := range resp.Data {
+		invitations = append(invitations, flattenInvitation(v))
 	}
+	return selectPendingInvitation(client.loginNormalization, invitations, email)
+}
 
-	return nil, nil
+// selectPendingInvitation returns the pending invitation sent to email, or
+// nil if there is none. Accepted and expired invitations stay in the list
+// until they are cleaned up, so they never match. Several pending
+// invitations for the same address are an error, as there is no telling
+// which one is meant.
+func selectPendingInvitation(rules LoginNormalization, invitations []*Invitation, email string) (*Invitation, error) {
+	pending := matchPendingInvitations(rules, invitations, email)
+	switch len(pending) {
+	case 0:
+		return nil, nil
+	case 1:
+		return pending[0], nil
+	default:
+		return nil, fmt.Errorf("%d pending invitations found for %s: %s", len(pending), email, invitationIDs(pending))
+	}
 }
 
 // API functions for invitations (using raw HTTP since go-fastly may not have these)
@@ -495,12 +1386,7 @@ func getInvitation(ctx context.Context, client *APIClient, invitationID string)
 
 	for _, inv := range invitations.Data {
 		if inv.ID == invitationID {
-			return &Invitation{
-				ID:         inv.ID,
-				Email:      inv.Attributes.Email,
-				Role:       inv.Attributes.Role,
-				StatusCode: inv.Attributes.StatusCode,
-			}, nil
+			return flattenInvitation(inv), nil
 		}
 	}
 
@@ -542,6 +1428,29 @@ func deleteInvitation(ctx context.Context, client *APIClient, invitationID strin
 	return nil
 }
 
+// reissueInvitation replaces a pending invitation with a new one for the
+// given role, since invitations cannot be updated in place. The new
+// invitation is created before the old one is deleted, so the invitee is
+// never left without one. If only the deletion fails, the new invitation is
+// returned along with the error, and callers must record it.
+func reissueInvitation(ctx context.Context, client *APIClient, invitationID, email, role string) (*invitationResponse, error) {
+	customerID, err := currentCustomerID(ctx, client.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := createInvitation(ctx, client, email, role, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deleteInvitation(ctx, client, invitationID); err != nil {
+		return invitation, fmt.Errorf("sent new invitation %s, but could not revoke the old invitation %s, which remains valid: %w", invitation.Data.ID, invitationID, err)
+	}
+
+	return invitation, nil
+}
+
 func doInvitationRequest(ctx context.Context, client *APIClient, method, path string, body []byte) (*http.Response, error) {
 	// Build the request URL using the client's base URL
 	url := client.conn.Address + path
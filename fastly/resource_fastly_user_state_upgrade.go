@@ -0,0 +1,90 @@
+package fastly
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceUserV0 is the fastly_user schema before versioning was introduced.
+// It also describes the state of fastly_user in the official
+// fastly/terraform-provider-fastly, which only has login, name and role.
+func resourceUserV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"login": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"invitation_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUserV0Type() cty.Type {
+	return resourceUserV0().CoreConfigSchema().ImpliedType()
+}
+
+// resourceUserStateUpgradeV0 fills in the attributes that state written by
+// the official provider lacks. Its ID is always a user ID, as that provider
+// creates users directly rather than through invitations. Arguments with
+// defaults are set as well, so that migrated resources do not show a diff.
+// The resource ID becomes the login, which stays the same when an invitation
+// is accepted or reissued; the previous ID remains available as user_id or
+// invitation_id.
+func resourceUserStateUpgradeV0(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	userID, _ := rawState["user_id"].(string)
+	invitationID, _ := rawState["invitation_id"].(string)
+	if userID == "" && invitationID == "" {
+		if id, _ := rawState["id"].(string); id != "" {
+			log.Printf("[DEBUG] Upgrading fastly_user %s state without user_id or invitation_id, treating the ID as a user ID", id)
+			rawState["user_id"] = id
+			rawState["invitation_id"] = ""
+		}
+	}
+
+	if login, _ := rawState["login"].(string); login != "" {
+		log.Printf("[DEBUG] Upgrading fastly_user ID from %v to %s", rawState["id"], login)
+		rawState["id"] = login
+	}
+
+	if role, _ := rawState["role"].(string); role == "" {
+		rawState["role"] = "user"
+	}
+
+	defaults := map[string]any{
+		"on_existing":                       onExistingAdopt,
+		"on_destroy":                        onDestroyDelete,
+		"deletion_protection":               false,
+		"wait_for_acceptance":               false,
+		"reissue_invitation_on_role_change": true,
+	}
+	for k, v := range defaults {
+		if _, ok := rawState[k]; !ok || rawState[k] == nil {
+			rawState[k] = v
+		}
+	}
+
+	return rawState, nil
+}
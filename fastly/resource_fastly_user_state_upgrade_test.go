@@ -0,0 +1,74 @@
+package fastly
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceUserStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name     string
+		rawState map[string]any
+		want     map[string]string
+	}{
+		{
+			name: "official provider state",
+			rawState: map[string]any{
+				"id":    "user123",
+				"login": "jane@example.com",
+				"name":  "Jane",
+				"role":  "engineer",
+			},
+			want: map[string]string{
+				"id":            "jane@example.com",
+				"user_id":       "user123",
+				"invitation_id": "",
+				"role":          "engineer",
+				"on_existing":   onExistingAdopt,
+				"on_destroy":    onDestroyDelete,
+			},
+		},
+		{
+			name: "pending invitation state",
+			rawState: map[string]any{
+				"id":            "inv123",
+				"login":         "jane@example.com",
+				"name":          "Jane",
+				"role":          "",
+				"invitation_id": "inv123",
+				"user_id":       "",
+			},
+			want: map[string]string{
+				"id":            "jane@example.com",
+				"user_id":       "",
+				"invitation_id": "inv123",
+				"role":          "user",
+			},
+		},
+		{
+			name: "existing arguments are kept",
+			rawState: map[string]any{
+				"id":          "user123",
+				"user_id":     "user123",
+				"on_existing": onExistingError,
+			},
+			want: map[string]string{
+				"user_id":     "user123",
+				"on_existing": onExistingError,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := resourceUserStateUpgradeV0(context.Background(), tc.rawState, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		for k, v := range tc.want {
+			if !reflect.DeepEqual(got[k], v) {
+				t.Errorf("%s: %s = %#v, want %#v", tc.name, k, got[k], v)
+			}
+		}
+	}
+}
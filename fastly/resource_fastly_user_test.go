@@ -3,10 +3,13 @@ package fastly
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	gofastly "github.com/fastly/go-fastly/v12/fastly"
@@ -35,6 +38,8 @@ func TestAccFastlyUser_invitation(t *testing.T) {
 				Config: testAccUserConfig(login, name, role),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckFastlyInvitationExists(),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "id", login),
 					resource.TestCheckResourceAttr(
 						fastlyUser, "login", login),
 					resource.TestCheckResourceAttr(
@@ -47,8 +52,161 @@ func TestAccFastlyUser_invitation(t *testing.T) {
 					// Verify user_id is empty (not yet accepted)
 					resource.TestCheckResourceAttr(
 						fastlyUser, "user_id", ""),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "planned_action", "invite"),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "adoption_warning", ""),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "status", "pending"),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "locked", "false"),
+				),
+			},
+			{
+				ResourceName:      fastlyUser,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccUserImportStateIDFunc("invitation", "invitation_id"),
+				// The name of an invitee is not known to the API, and the
+				// planned action and adoption warning are only recorded on create.
+				ImportStateVerifyIgnore: []string{"name", "planned_action", "adoption_warning"},
+			},
+			{
+				ResourceName:            fastlyUser,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccUserImportStateIDFunc("login", "login"),
+				ImportStateVerifyIgnore: []string{"name", "planned_action", "adoption_warning"},
+			},
+			{
+				// The resource ID, which is the login, is itself a valid
+				// import ID.
+				ResourceName:            fastlyUser,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name", "planned_action", "adoption_warning"},
+			},
+		},
+	})
+}
+
+// TestAccFastlyUser_invitationUpdate tests that the name and role can be
+// changed while the invitation is pending, the latter by sending a new
+// invitation.
+func TestAccFastlyUser_invitationUpdate(t *testing.T) {
+	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	name2 := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	var invitationID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserOrInvitationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig(login, name, "engineer"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFastlyInvitationExists(),
+					testAccCheckFastlyUserAttr("invitation_id", &invitationID),
+				),
+			},
+			{
+				Config: testAccUserConfig(login, name2, "superuser"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFastlyInvitationExists(),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "name", name2),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "role", "superuser"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[fastlyUser]
+						if rs.Primary.Attributes["invitation_id"] == invitationID {
+							return fmt.Errorf("expected invitation %s to be reissued", invitationID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestAccFastlyUser_deletionProtection tests that a protected resource
+// cannot be destroyed until the protection is turned off.
+func TestAccFastlyUser_deletionProtection(t *testing.T) {
+	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserOrInvitationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserDeletionProtectionConfig(login, name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFastlyInvitationExists(),
+					resource.TestCheckResourceAttr(
+						fastlyUser, "deletion_protection", "true"),
 				),
 			},
+			{
+				Config:      testAccUserDeletionProtectionConfig(login, name, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`deletion_protection is enabled`),
+			},
+			{
+				Config: testAccUserDeletionProtectionConfig(login, name, false),
+				Check: resource.TestCheckResourceAttr(
+					fastlyUser, "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+// TestAccFastlyUser_waitForAcceptanceTimeout tests that waiting for an
+// invitation nobody accepts fails once the create timeout is reached.
+func TestAccFastlyUser_waitForAcceptanceTimeout(t *testing.T) {
+	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserOrInvitationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserWaitForAcceptanceConfig(login, name, "15s"),
+				ExpectError: regexp.MustCompile(`error waiting for .* to accept the invitation`),
+			},
+		},
+	})
+}
+
+// TestAccFastlyUser_onExistingError tests that a second resource for the same
+// login fails instead of adopting the invitation created by the first.
+func TestAccFastlyUser_onExistingError(t *testing.T) {
+	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserOrInvitationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserOnExistingErrorConfig(login, name),
+				ExpectError: regexp.MustCompile(`already pending`),
+			},
 		},
 	})
 }
@@ -61,6 +219,190 @@ func TestAccFastlyUser_existingUser(t *testing.T) {
 	t.Skip("Skipping: requires pre-existing user")
 }
 
+func TestAdoptUserMismatch(t *testing.T) {
+	user := &gofastly.User{
+		UserID: gofastly.ToPointer("123"),
+		Name:   gofastly.ToPointer("Jane Doe"),
+		Role:   gofastly.ToPointer("superuser"),
+	}
+
+	if got := adoptUserMismatch(user, "Jane Doe", "superuser"); got != "" {
+		t.Errorf("expected no mismatch, got %q", got)
+	}
+
+	want := "will adopt existing user 123 with role superuser, config says engineer"
+	if got := adoptUserMismatch(user, "Jane Doe", "engineer"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := adoptionWarning(want, onExistingAdopt); got != want {
+		t.Errorf("adoptionWarning: got %q, want %q", got, want)
+	}
+	if got, want := adoptionWarning(want, onExistingAdoptAndReconcile), want+" (will be reconciled to match the config)"; got != want {
+		t.Errorf("adoptionWarning: got %q, want %q", got, want)
+	}
+	if got := adoptionWarning("", onExistingAdoptAndReconcile); got != "" {
+		t.Errorf("adoptionWarning: expected no warning, got %q", got)
+	}
+}
+
+func TestUserNameNotConfigured(t *testing.T) {
+	// State of a user who accepted their invitation and chose a name
+	state := &terraform.InstanceState{
+		ID: "jane@example.com",
+		Attributes: map[string]string{
+			"id":                                "jane@example.com",
+			"login":                             "jane@example.com",
+			"name":                              "Jane Doe",
+			"role":                              "engineer",
+			"user_id":                           "user123",
+			"invitation_id":                     "",
+			"on_existing":                       onExistingAdopt,
+			"on_destroy":                        onDestroyDelete,
+			"deletion_protection":               "false",
+			"wait_for_acceptance":               "false",
+			"reissue_invitation_on_role_change": "true",
+		},
+	}
+
+	cases := []struct {
+		name     string
+		config   map[string]any
+		wantDiff bool
+	}{
+		{"not configured", map[string]any{"login": "jane@example.com", "role": "engineer"}, false},
+		{"same name", map[string]any{"login": "jane@example.com", "name": "Jane Doe", "role": "engineer"}, false},
+		{"other name", map[string]any{"login": "jane@example.com", "name": "Jane Smith", "role": "engineer"}, true},
+	}
+
+	for _, tc := range cases {
+		diff, err := resourceUser(nil).Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), nil)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		}
+		gotDiff := diff != nil && diff.Attributes["name"] != nil
+		if gotDiff != tc.wantDiff {
+			t.Errorf("%s: name diff = %t, want %t", tc.name, gotDiff, tc.wantDiff)
+		}
+	}
+
+	user := &gofastly.User{
+		UserID: gofastly.ToPointer("123"),
+		Name:   gofastly.ToPointer("Jane Doe"),
+		Role:   gofastly.ToPointer("engineer"),
+	}
+	if got := adoptUserMismatch(user, "", "engineer"); got != "" {
+		t.Errorf("adoptUserMismatch without a name: expected no mismatch, got %q", got)
+	}
+}
+
+func TestSetUserIdentity(t *testing.T) {
+	d := schema.TestResourceDataWithIdentityRaw(t, resourceUser(nil).SchemaMap(), resourceUserIdentitySchema(), map[string]string{})
+	d.SetId("Jane.Doe@Example.com")
+	if err := d.Set("customer_id", "cust123"); err != nil {
+		t.Fatal(err)
+	}
+	// The API may report the login in a different case than configured
+	if err := d.Set("login", "jane.doe@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setUserIdentity(d); err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := identity.Get("customer_id"); got != "cust123" {
+		t.Errorf("customer_id = %v, want cust123", got)
+	}
+	if got := identity.Get("login"); got != "Jane.Doe@Example.com" {
+		t.Errorf("login = %v, want Jane.Doe@Example.com", got)
+	}
+}
+
+func TestInvitationStatus(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	cases := []struct {
+		name string
+		inv  Invitation
+		want string
+	}{
+		{"pending", Invitation{StatusCode: invitationStatusActive, ExpiresAt: future}, "pending"},
+		{"expired", Invitation{StatusCode: invitationStatusActive, ExpiresAt: past}, "expired"},
+		{"accepted", Invitation{StatusCode: invitationStatusInactive, ExpiresAt: future}, "inactive"},
+		{"accepted after expiry", Invitation{StatusCode: invitationStatusInactive, ExpiresAt: past}, "expired"},
+		{"no expires_at", Invitation{StatusCode: invitationStatusActive}, "pending"},
+		{"invalid expires_at", Invitation{StatusCode: invitationStatusActive, ExpiresAt: "soon"}, "pending"},
+		{"unknown status code", Invitation{StatusCode: 7}, "unknown"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.inv.Status(); got != tc.want {
+			t.Errorf("%s: Status() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestInvitationAge(t *testing.T) {
+	cases := []struct {
+		name      string
+		createdAt string
+		min, max  time.Duration
+	}{
+		{"two weeks", time.Now().Add(-336 * time.Hour).Format(time.RFC3339), 335 * time.Hour, 337 * time.Hour},
+		{"no created_at", "", 0, 0},
+		{"invalid created_at", "yesterday", 0, 0},
+	}
+
+	for _, tc := range cases {
+		inv := Invitation{CreatedAt: tc.createdAt}
+		if got := inv.Age(); got < tc.min || got > tc.max {
+			t.Errorf("%s: Age() = %s, want between %s and %s", tc.name, got, tc.min, tc.max)
+		}
+	}
+}
+
+func TestSelectPendingInvitation(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	accepted := &Invitation{ID: "accepted", Email: "jane@example.com", StatusCode: invitationStatusInactive, ExpiresAt: future}
+	expired := &Invitation{ID: "expired", Email: "jane@example.com", StatusCode: invitationStatusActive, ExpiresAt: past}
+	pending := &Invitation{ID: "pending", Email: "Jane@example.com", StatusCode: invitationStatusActive, ExpiresAt: future}
+	other := &Invitation{ID: "other", Email: "jane@example.com", StatusCode: invitationStatusActive, ExpiresAt: future}
+
+	cases := []struct {
+		name        string
+		invitations []*Invitation
+		want        string
+		wantErr     string
+	}{
+		{"accepted only", []*Invitation{accepted}, "", ""},
+		{"expired only", []*Invitation{expired}, "", ""},
+		{"pending after accepted", []*Invitation{accepted, expired, pending}, "pending", ""},
+		{"several pending", []*Invitation{pending, accepted, other}, "", "2 pending invitations found for jane@example.com: pending, other"},
+	}
+
+	for _, tc := range cases {
+		inv, err := selectPendingInvitation(DefaultLoginNormalization, tc.invitations, "jane@example.com")
+		var got, gotErr string
+		if inv != nil {
+			got = inv.ID
+		}
+		if err != nil {
+			gotErr = err.Error()
+		}
+		if got != tc.want || gotErr != tc.wantErr {
+			t.Errorf("%s: selectPendingInvitation() = %q, %q; want %q, %q", tc.name, got, gotErr, tc.want, tc.wantErr)
+		}
+	}
+}
+
 // testAccCheckFastlyInvitationExists verifies that either an invitation
 // or a user exists for the resource.
 func testAccCheckFastlyInvitationExists() resource.TestCheckFunc {
@@ -194,6 +536,73 @@ func testAccCheckUserDestroy(s *terraform.State) error {
 	return testAccCheckUserOrInvitationDestroy(s)
 }
 
+// testAccCheckFastlyUserAttr copies an attribute of the fastly_user resource
+// into v, for comparison in later steps.
+func testAccCheckFastlyUserAttr(attr string, v *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[fastlyUser]
+		if !ok {
+			return fmt.Errorf("not found: %s", fastlyUser)
+		}
+		*v = rs.Primary.Attributes[attr]
+		return nil
+	}
+}
+
+// testAccUserImportStateIDFunc builds a <kind>/<value> import ID from an
+// attribute of the fastly_user resource.
+func testAccUserImportStateIDFunc(kind, attr string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[fastlyUser]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", fastlyUser)
+		}
+		return kind + "/" + rs.Primary.Attributes[attr], nil
+	}
+}
+
+func testAccUserOnExistingErrorConfig(login, name string) string {
+	return fmt.Sprintf(`
+resource "fastly_user" "foo" {
+	login = "%[1]s"
+	name  = "%[2]s"
+	role  = "engineer"
+}
+
+resource "fastly_user" "bar" {
+	login       = "%[1]s"
+	name        = "%[2]s"
+	role        = "engineer"
+	on_existing = "error"
+
+	depends_on = [fastly_user.foo]
+}`, login, name)
+}
+
+func testAccUserDeletionProtectionConfig(login, name string, protected bool) string {
+	return fmt.Sprintf(`
+resource "fastly_user" "foo" {
+	login               = "%s"
+	name                = "%s"
+	role                = "engineer"
+	deletion_protection = %t
+}`, login, name, protected)
+}
+
+func testAccUserWaitForAcceptanceConfig(login, name, timeout string) string {
+	return fmt.Sprintf(`
+resource "fastly_user" "foo" {
+	login               = "%s"
+	name                = "%s"
+	role                = "engineer"
+	wait_for_acceptance = true
+
+	timeouts {
+		create = "%s"
+	}
+}`, login, name, timeout)
+}
+
 func testAccUserConfig(login, name, role string) string {
 	return fmt.Sprintf(`
 resource "fastly_user" "foo" {
@@ -1,17 +1,105 @@
 package fastly
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// userRoles lists the user roles from least to most privileged.
+var userRoles = []string{
+	"user",
+	"billing",
+	"engineer",
+	"superuser",
+}
+
+// roleRank returns the position of role in userRoles, starting at 1 for the
+// least privileged role.
+func roleRank(role string) (int, error) {
+	for i, r := range userRoles {
+		if r == role {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown role %q, expected one of %s", role, strings.Join(userRoles, ", "))
+}
+
 func validateUserRole() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice(userRoles, false))
+}
+
+// userRoleValidator is validateUserRole for the attributes of the
+// plugin-framework resources.
+type userRoleValidator struct{}
+
+func (userRoleValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of %s", strings.Join(userRoles, ", "))
+}
+
+func (v userRoleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (userRoleValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := roleRank(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid role", err.Error())
+	}
+}
+
+func validateDuration() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i any, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+		if _, err := time.ParseDuration(v); err != nil {
+			return nil, []error{fmt.Errorf("%s: invalid duration %q: %w", k, v, err)}
+		}
+		return nil, nil
+	})
+}
+
+func validateContactType() schema.SchemaValidateDiagFunc {
 	return validation.ToDiagFunc(validation.StringInSlice(
 		[]string{
-			"user",
+			"primary",
 			"billing",
-			"engineer",
-			"superuser",
+			"technical",
+			"security",
+			"emergency",
+			"general compliance",
+		},
+		false,
+	))
+}
+
+func validateOnExisting() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice(
+		[]string{
+			onExistingAdopt,
+			onExistingError,
+			onExistingAdoptAndReconcile,
+		},
+		false,
+	))
+}
+
+func validateOnDestroy() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice(
+		[]string{
+			onDestroyDelete,
+			onDestroyLock,
+			onDestroyDemote,
+			onDestroyAbandon,
 		},
 		false,
 	))
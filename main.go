@@ -2,30 +2,48 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 
 	"github.com/fastly/terraform-provider-fastly-user-mgt/fastly"
+	"github.com/fastly/terraform-provider-fastly-user-mgt/version"
 )
 
 const noLogPrefix = 0
 
+// providerAddress is the registry address the provider is published under.
+const providerAddress = "registry.terraform.io/fastly/fastly-user-mgt"
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: fastly.Provider}
-
 	// Prevent logger from prepending date/time to logs, which breaks log-level parsing/filtering
 	log.SetFlags(noLogPrefix)
 
+	ctx := context.Background()
+
+	providerServer, err := fastly.NewMuxServer(ctx, version.ProviderVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		opts.Debug = true
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	if err := tf5server.Serve(providerAddress, providerServer, serveOpts...); err != nil {
+		log.Fatal(err)
+	}
 }